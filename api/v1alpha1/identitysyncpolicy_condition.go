@@ -9,6 +9,10 @@ const (
 	ConditionReady                ConditionType = "Ready"
 	ConditionDegraded             ConditionType = "Degraded"
 	ConditionReferenceSecretReady ConditionType = "ReferenceSecretReady"
+	ConditionTargetConflict       ConditionType = "TargetConflict"
+	ConditionSuspended            ConditionType = "Suspended"
+	ConditionPaused               ConditionType = "Paused"
+	ConditionDryRun               ConditionType = "DryRun"
 )
 
 type ConditionReason string
@@ -20,6 +24,12 @@ const (
 	ReasonSecretNotFound  ConditionReason = "SecretNotFound"
 	ReasonSecretAvailable ConditionReason = "SecretAvailable"
 	ReasonSecretGetFailed ConditionReason = "SecretAvailable"
+	ReasonTargetConflict  ConditionReason = "TargetConflict"
+	ReasonNoConflict      ConditionReason = "NoConflict"
+	ReasonSuspended       ConditionReason = "Suspended"
+	ReasonPaused          ConditionReason = "Paused"
+	ReasonDryRun          ConditionReason = "DryRun"
+	ReasonActive          ConditionReason = "Active"
 
 	RBACForbidden ConditionReason = "RBACForbidden"
 )
@@ -11,17 +11,152 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // IdentitySyncPolicySpec defines the desired state of IdentitySyncPolicy
+// +kubebuilder:validation:XValidation:rule="has(self.targetNamespaces) || has(self.namespaceSelector)",message="either targetNamespaces or namespaceSelector must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.secret) || has(self.secrets)",message="either secret or secrets must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.secret) || !has(self.secrets) || self.secrets.all(s, s.name != self.secret.name)",message="secret names must be unique across secret and secrets"
 type IdentitySyncPolicySpec struct {
 	// targetNamespaces is the list of namespaces to sync into.
+	// +optional
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=50
 	// +kubebuilder:validation:Items:MinLength=1
 	// +kubebuilder:validation:Items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
 	// +listType=set
-	TargetNamespaces []string `json:"targetNamespaces"`
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+
+	// namespaceSelector selects additional namespaces to sync into by label.
+	// Matching namespaces are resolved on every reconcile and unioned with targetNamespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// createNamespaces creates namespaces listed in targetNamespaces that do not exist.
+	// +optional
+	CreateNamespaces CreateNamespaces `json:"createNamespaces,omitzero"`
 
 	ServiceAccount ServiceAccount `json:"serviceAccount"`
-	Secret         Secret         `json:"secret"`
+
+	// secret is a single Secret to sync. Kept for compatibility; prefer secrets.
+	// +optional
+	Secret Secret `json:"secret,omitzero"`
+
+	// secrets are Secrets to sync into every target namespace.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Secrets []Secret `json:"secrets,omitempty"`
+
+	// deletionPolicy controls what happens to target Secrets and ServiceAccounts
+	// when the policy is deleted.
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// conflictPolicy controls what happens when a target namespace already holds
+	// a Secret with the target name that this policy does not manage.
+	// +optional
+	// +kubebuilder:default=Adopt
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// fanoutParallelism overrides the operator-wide number of target namespaces
+	// reconciled concurrently for this policy.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	FanoutParallelism *int32 `json:"fanoutParallelism,omitempty"`
+
+	// suspend stops reconciliation of the policy. Targets are left as they are
+	// and nothing is written until it is unset. Deletion is still processed.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// dryRun runs the fan-out without writing. The changes a real run would make
+	// are reported in status.dryRun.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// retryPolicy overrides the operator-wide requeue delays of this policy
+	// when a reconcile fails.
+	// +optional
+	RetryPolicy RetryPolicy `json:"retryPolicy,omitzero"`
+}
+
+// RetryPolicy tunes how a failing policy is retried. Unset fields use the
+// operator configuration.
+type RetryPolicy struct {
+	// transientDelay is the requeue delay after failures that may resolve on
+	// their own, such as timeouts or conflicts.
+	// +optional
+	TransientDelay *metav1.Duration `json:"transientDelay,omitempty"`
+
+	// permanentDelay is the requeue delay after failures that need a fix, such
+	// as missing RBAC or an invalid template.
+	// +optional
+	PermanentDelay *metav1.Duration `json:"permanentDelay,omitempty"`
+
+	// maxBackoff caps every requeue delay, jitter included. Target namespaces
+	// that keep failing double their delay up to it.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// jitter adds a random part of up to this percentage of the delay, so
+	// policies and namespaces failing together are not retried together.
+	// Zero turns jitter off.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Jitter *int32 `json:"jitter,omitempty"`
+}
+
+// CreateNamespaces configures creation of missing target namespaces.
+type CreateNamespaces struct {
+	// enabled creates missing namespaces listed in targetNamespaces. Namespaces
+	// matched by namespaceSelector already exist and are never created.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// labels are set on created namespaces next to the operator's management labels.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// annotations are set on created namespaces.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ConflictPolicy is how a pre-existing, unmanaged target Secret is handled.
+// +kubebuilder:validation:Enum=Adopt;Skip;Fail
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyAdopt takes over the existing Secret and overwrites its data.
+	ConflictPolicyAdopt ConflictPolicy = "Adopt"
+	// ConflictPolicySkip leaves the existing Secret untouched and reports the namespace as skipped.
+	ConflictPolicySkip ConflictPolicy = "Skip"
+	// ConflictPolicyFail leaves the existing Secret untouched and fails the namespace.
+	ConflictPolicyFail ConflictPolicy = "Fail"
+)
+
+// DeletionPolicy is what happens to targets when their policy is deleted.
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes every target before the policy is removed.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan strips ownerReferences and management labels, leaving
+	// targets as plain unmanaged objects.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// AllSecrets returns spec.secret, when set, followed by spec.secrets.
+func (s *IdentitySyncPolicySpec) AllSecrets() []Secret {
+	secrets := make([]Secret, 0, len(s.Secrets)+1)
+	if s.Secret.Name != "" {
+		secrets = append(secrets, s.Secret)
+	}
+	return append(secrets, s.Secrets...)
 }
 
 type ServiceAccount struct {
@@ -36,6 +171,57 @@ type Secret struct {
 	Name string `json:"name"`
 
 	SourceRef NamespacedNameRef `json:"sourceRef"`
+
+	// keys selects and renames the source keys copied to targets.
+	// All keys are copied unchanged when unset.
+	// +optional
+	Keys *SecretKeys `json:"keys,omitempty"`
+
+	// template renders additional target keys with Go text/template.
+	// Templates see .Data (every source key as a string, before filtering)
+	// and .Namespace (the target namespace). Rendered keys replace copied keys
+	// of the same name.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=64
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[-._a-zA-Z0-9]+$'))",message="template keys must be valid Secret keys"
+	Template map[string]string `json:"template,omitempty"`
+}
+
+// SecretKeys selects and renames source Secret keys.
+// include and exclude are matched against source key names, before rename.
+type SecretKeys struct {
+	// include lists the only source keys to copy.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:Items:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +listType=set
+	Include []string `json:"include,omitempty"`
+
+	// exclude lists source keys that are never copied.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:Items:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +listType=set
+	Exclude []string `json:"exclude,omitempty"`
+
+	// rename copies source keys under a different target key.
+	// A renamed key replaces a copied key of the same name.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:XValidation:rule="self.all(r, self.exists_one(o, o.to == r.to))",message="rename targets must be unique"
+	// +listType=map
+	// +listMapKey=from
+	Rename []KeyRename `json:"rename,omitempty"`
+}
+
+type KeyRename struct {
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	From string `json:"from"`
+
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	To string `json:"to"`
 }
 
 type NamespacedNameRef struct {
@@ -48,16 +234,151 @@ type NamespacedNameRef struct {
 	Namespace string `json:"namespace"`
 }
 
+// TargetState is the sync state of a single target namespace.
+// +kubebuilder:validation:Enum=Synced;Failed;Skipped;NamespaceMissing;Terminating
+type TargetState string
+
+const (
+	TargetStateSynced  TargetState = "Synced"
+	TargetStateFailed  TargetState = "Failed"
+	TargetStateSkipped TargetState = "Skipped"
+	// TargetStateNamespaceMissing is a listed namespace that does not exist yet.
+	// It is synced as soon as the namespace is created.
+	TargetStateNamespaceMissing TargetState = "NamespaceMissing"
+	// TargetStateTerminating is a namespace being deleted. Nothing is written
+	// into it and it does not count as a failure.
+	TargetStateTerminating TargetState = "Terminating"
+)
+
+// TargetStatus reports the sync state of a single target namespace.
+type TargetStatus struct {
+	Namespace string      `json:"namespace"`
+	State     TargetState `json:"state"`
+	// SyncedHash is a hash of the Secret data last successfully written to the namespace.
+	// +optional
+	SyncedHash string `json:"syncedHash,omitempty"`
+	// LastSyncTime is the time SyncedHash was last written.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ConsecutiveFailures is the number of fan-outs in a row that failed in
+	// this namespace.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// NextRetryTime is when a failed namespace is written into again. Until
+	// then fan-outs skip it and keep reporting its last failure.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// DryRunStatus reports the changes a real fan-out would have made.
+type DryRunStatus struct {
+	// Namespaces lists the planned changes per namespace. Namespaces without
+	// changes are omitted.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []NamespaceChanges `json:"namespaces,omitempty"`
+}
+
+// NamespaceChanges are the planned writes in one namespace, as Kind/name references.
+type NamespaceChanges struct {
+	Namespace string `json:"namespace"`
+	// +optional
+	WouldCreate []string `json:"wouldCreate,omitempty"`
+	// +optional
+	WouldUpdate []string `json:"wouldUpdate,omitempty"`
+	// +optional
+	WouldDelete []string `json:"wouldDelete,omitempty"`
+}
+
+// Propagation tracks a source change from the reconcile that first observed it
+// until it was synced to every target namespace.
+type Propagation struct {
+	// SourceHash is the combined source hash being propagated.
+	SourceHash string `json:"sourceHash"`
+	// StartTime is when the change was first observed.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is when the change was synced to every target namespace.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration is CompletionTime - StartTime.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// SecretStatus reports the state of a single source Secret.
+type SecretStatus struct {
+	// Name is the target Secret name the source is synced to.
+	Name string `json:"name"`
+	// Available is whether the source Secret could be read on the last reconcile.
+	Available bool `json:"available"`
+	// ObservedHash is a hash of the last successfully applied source data.
+	// +optional
+	ObservedHash string `json:"observedHash,omitempty"`
+}
+
 // IdentitySyncPolicyStatus defines the observed state of IdentitySyncPolicy.
 type IdentitySyncPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// ObservedSourceSecretHash is a hash of the last successfully applied source Secret data.
+	// ObservedSourceSecretHash is a combined hash of the last successfully applied source Secrets.
 	ObservedSourceSecretHash string `json:"observedSourceSecretHash,omitempty"`
+	// ObservedTargetsHash is a hash of the resolved target namespaces the source Secret was last applied to.
+	ObservedTargetsHash string `json:"observedTargetsHash,omitempty"`
+	// Secrets reports the state of every source Secret.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Secrets []SecretStatus `json:"secrets,omitempty"`
+	// PrunedTargets is the number of stale target objects deleted during the last fan-out.
+	PrunedTargets int32 `json:"prunedTargets,omitempty"`
+
+	// Targets reports the sync state of the target namespaces from the last
+	// fan-out. Beyond the operator's fanout.maxTargetStatuses, synced and then
+	// skipped namespaces are left out; failed ones are always listed.
+	// +optional
+	// +kubebuilder:validation:MaxItems=1000
+	// +listType=map
+	// +listMapKey=namespace
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// TargetsSourceHash is the combined hash of the source Secrets the last
+	// fan-out wrote, the one Targets describes.
+	// +optional
+	TargetsSourceHash string `json:"targetsSourceHash,omitempty"`
+	// Desired is the number of resolved target namespaces.
+	Desired int32 `json:"desired,omitempty"`
+	// Synced is the number of target namespaces in the Synced state.
+	Synced int32 `json:"synced,omitempty"`
+	// Failed is the number of target namespaces in the Failed state.
+	Failed int32 `json:"failed,omitempty"`
+
+	// DryRun reports the outcome of the last dry-run fan-out. It is cleared by
+	// the first real fan-out.
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+
+	// PendingPropagation is the source change that has not reached every target
+	// namespace yet. Its StartTime is kept across partial fan-outs and further
+	// source changes, so it is the oldest change still missing somewhere.
+	// +optional
+	PendingPropagation *Propagation `json:"pendingPropagation,omitempty"`
+	// LastPropagation is the last source change synced to every target namespace.
+	// +optional
+	LastPropagation *Propagation `json:"lastPropagation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desired`
+// +kubebuilder:printcolumn:name="Synced",type=integer,JSONPath=`.status.synced`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // IdentitySyncPolicy is the Schema for the identitysyncpolicies API
 // +kubebuilder:resource:scope=Cluster
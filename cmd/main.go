@@ -4,20 +4,30 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -25,7 +35,13 @@ import (
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 	"github.com/lapacek-labs/identity-operator/internal/controller"
+	webhookv1alpha1 "github.com/lapacek-labs/identity-operator/internal/webhook/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
 	"github.com/lapacek-labs/identity-operator/pkg/logging"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/observability/noop"
+	"github.com/lapacek-labs/identity-operator/pkg/observability/otlp"
 	"github.com/lapacek-labs/identity-operator/pkg/observability/prom"
 	// +kubebuilder:scaffold:imports
 )
@@ -51,6 +67,16 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var protectedNamespaces string
+	var fanoutParallelism int
+	var pauseConfigMap string
+	var dryRun bool
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var traceSampleRatio float64
+	var metricsNamespaceTopN int
+	var configPath string
+	var configReloadInterval time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -69,6 +95,28 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&protectedNamespaces, "protected-namespaces", strings.Join(guard.DefaultProtectedNamespaces, ","),
+		"Comma separated list of namespaces that policies must never target, write into or create.")
+	flag.IntVar(&fanoutParallelism, "fanout-parallelism", 0,
+		"Number of target namespaces reconciled concurrently per policy. spec.fanoutParallelism overrides it. "+
+			"0 uses fanout.parallelism from the config file.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Run every policy as a dry run: writes are sent as server-side dry runs and reported in status.dryRun.")
+	flag.StringVar(&pauseConfigMap, "pause-configmap", "identity-operator-system/identity-operator-pause",
+		"The <namespace>/<name> of the ConfigMap whose \""+controller.PauseKey+"\" key set to \"true\" "+
+			"stops all writes. Empty disables the pause switch.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of an OTLP gRPC collector reconcile traces are exported to. Empty disables tracing.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "If set, traces are exported without TLS.")
+	flag.Float64Var(&traceSampleRatio, "trace-sample-ratio", 1,
+		"The fraction of reconciles traced, from 0 to 1.")
+	flag.StringVar(&configPath, "config", "",
+		"The path of the operator config file. Empty uses the built-in defaults.")
+	flag.DurationVar(&configReloadInterval, "config-reload-interval", 10*time.Second,
+		"How often the config file is checked for changes.")
+	flag.IntVar(&metricsNamespaceTopN, "metrics-namespace-top-n", 0,
+		"Number of target namespaces with the most failing policies exposed with a namespace label. "+
+			"0 exposes no per-namespace metrics.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -144,8 +192,32 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	operatorConfig, err := config.Load(configPath)
+	if err != nil {
+		setupLog.Error(err, "unable to load config", "path", configPath)
+		os.Exit(1)
+	}
+	configStore := config.NewStore(operatorConfig)
+
+	pauseRef, err := parseNamespacedName(pauseConfigMap)
+	if err != nil {
+		setupLog.Error(err, "invalid --pause-configmap")
+		os.Exit(1)
+	}
+	cacheOptions := cache.Options{}
+	if pauseRef.Name != "" {
+		// Only the pause ConfigMap is cached, not every ConfigMap in the cluster.
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{pauseRef.Namespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", pauseRef.Name),
+			},
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -168,15 +240,69 @@ func main() {
 		os.Exit(1)
 	}
 
+	if configPath != "" {
+		if err := mgr.Add(&config.Reloader{
+			Path:     configPath,
+			Store:    configStore,
+			Interval: configReloadInterval,
+			Log:      ctrl.Log.WithName("config"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up config reload")
+			os.Exit(1)
+		}
+	}
+
+	var tracer observability.Tracer = noop.Tracer{}
+	if otlpEndpoint != "" {
+		provider, err := otlp.NewTracerProvider(context.Background(), otlp.Options{
+			Endpoint:    otlpEndpoint,
+			Insecure:    otlpInsecure,
+			SampleRatio: traceSampleRatio,
+			ServiceName: "identity-operator",
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		// Flush the spans still buffered once the manager stops.
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return provider.Shutdown(shutdownCtx)
+		})); err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		tracer = otlp.NewTracer(provider)
+	}
+
+	protected := guard.ParseNamespaces(protectedNamespaces)
 	if err := (controller.NewController(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		logging.NewLimiter(1000),
-		prom.NewRecorder(crmetrics.Registry),
+		logging.NewLimiter(operatorConfig.Logging.LimiterSize),
+		prom.NewRecorder(crmetrics.Registry, prom.Options{NamespaceTopN: metricsNamespaceTopN}),
+		tracer,
+		mgr.GetEventRecorderFor("identity-sync-policy"),
+		controller.Options{
+			FanoutParallelism:   fanoutParallelism,
+			ProtectedNamespaces: protected,
+			PauseConfigMap:      pauseRef,
+			DryRun:              dryRun,
+			Config:              configStore,
+		},
 	)).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IdentitySyncPolicy")
 		os.Exit(1)
 	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupIdentitySyncPolicyWebhookWithManager(mgr, protected); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IdentitySyncPolicy")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -194,3 +320,16 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// parseNamespacedName parses a "<namespace>/<name>" flag value. An empty value
+// yields an empty name.
+func parseNamespacedName(value string) (types.NamespacedName, error) {
+	if value == "" {
+		return types.NamespacedName{}, nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("expected <namespace>/<name>, got %q", value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+// minRetryAfter keeps a namespace that is due now from requeueing with zero,
+// which would not requeue at all.
+const minRetryAfter = time.Second
+
+// failureHistory returns the target statuses failed namespaces are backed off
+// from.
+//
+// --- Reset on spec or source change ---
+// The history only counts while the policy is degraded at its current
+// generation and the sources are the ones the failed fan-out wrote. Editing the
+// spec or rotating a source retries every namespace at once and restarts their
+// backoff: the edit may be the fix, and a rotated source must not wait out a
+// backoff of up to maxBackoff before it reaches the namespaces.
+func failureHistory(identity *v1alpha1.IdentitySyncPolicy, currentHash string) []v1alpha1.TargetStatus {
+	if !isCurrentAndEqual(identity.Status.Conditions, v1alpha1.ConditionDegraded, metav1.ConditionTrue,
+		identity.GetGeneration()) {
+		return nil
+	}
+	if identity.Status.TargetsSourceHash != currentHash {
+		return nil
+	}
+	return identity.Status.Targets
+}
+
+// targetsInBackoff returns the failed namespaces not due for a retry at now,
+// by namespace.
+func targetsInBackoff(history []v1alpha1.TargetStatus, now time.Time) map[string]v1alpha1.TargetStatus {
+	var backoff map[string]v1alpha1.TargetStatus
+	for _, target := range history {
+		if target.State != v1alpha1.TargetStateFailed || target.NextRetryTime == nil ||
+			!target.NextRetryTime.After(now) {
+			continue
+		}
+		if backoff == nil {
+			backoff = make(map[string]v1alpha1.TargetStatus)
+		}
+		backoff[target.Namespace] = target
+	}
+	return backoff
+}
+
+// scheduleRetries sets when every namespace that failed in this fan-out is
+// retried, doubling its delay for each failure in a row, and sets
+// obs.RetryAfter to the earliest due time. Namespaces skipped in backoff keep
+// their schedule.
+func (p Policy) scheduleRetries(obs *Observation, history []v1alpha1.TargetStatus, now time.Time) {
+	failures := make(map[string]int32, len(history))
+	for _, target := range history {
+		if target.State == v1alpha1.TargetStateFailed {
+			failures[target.Namespace] = target.ConsecutiveFailures
+		}
+	}
+
+	var earliest time.Time
+	for i := range obs.Results {
+		res := &obs.Results[i]
+		if !res.Failed {
+			continue
+		}
+		if res.RetryAt.IsZero() {
+			transient := res.Kind == errclass.KindTransient || res.Kind == errclass.KindConflict
+			res.Failures = failures[res.Namespace] + 1
+			res.RetryAt = now.Add(p.Backoff(transient, res.Failures))
+		}
+		if earliest.IsZero() || res.RetryAt.Before(earliest) {
+			earliest = res.RetryAt
+		}
+	}
+	if !earliest.IsZero() {
+		obs.RetryAfter = max(earliest.Sub(now), minRetryAfter)
+	}
+}
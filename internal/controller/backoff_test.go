@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+)
+
+func TestPolicyBackoff(t *testing.T) {
+	policy := Policy{TransientDelay: time.Minute, PermanentDelay: 10 * time.Minute, MaxBackoff: time.Hour}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		transient bool
+		failures  int32
+		want      time.Duration
+	}{
+		{name: "first_failure", policy: policy, failures: 1, want: 10 * time.Minute},
+		{name: "doubles", policy: policy, failures: 3, want: 40 * time.Minute},
+		{name: "transient_doubles", policy: policy, transient: true, failures: 4, want: 8 * time.Minute},
+		{name: "capped", policy: policy, failures: 5, want: time.Hour},
+		{
+			name:      "uncapped_stops_doubling",
+			policy:    Policy{TransientDelay: time.Minute},
+			transient: true,
+			failures:  1000,
+			want:      1024 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Backoff(tt.transient, tt.failures); got != tt.want {
+				t.Fatalf("Backoff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureHistory(t *testing.T) {
+	targets := []v1alpha1.TargetStatus{{Namespace: "app-a", State: v1alpha1.TargetStateFailed}}
+	degraded := func(status metav1.ConditionStatus, generation int64) *v1alpha1.IdentitySyncPolicy {
+		identity := &v1alpha1.IdentitySyncPolicy{}
+		identity.Generation = 2
+		identity.Status.Targets = targets
+		identity.Status.TargetsSourceHash = "hash"
+		identity.Status.Conditions = []metav1.Condition{{
+			Type:               string(v1alpha1.ConditionDegraded),
+			Status:             status,
+			ObservedGeneration: generation,
+		}}
+		return identity
+	}
+
+	tests := []struct {
+		name        string
+		identity    *v1alpha1.IdentitySyncPolicy
+		currentHash string
+		want        int
+	}{
+		{name: "degraded", identity: degraded(metav1.ConditionTrue, 2), currentHash: "hash", want: 1},
+		{name: "spec_changed", identity: degraded(metav1.ConditionTrue, 1), currentHash: "hash"},
+		{name: "source_changed", identity: degraded(metav1.ConditionTrue, 2), currentHash: "rotated"},
+		{name: "not_degraded", identity: degraded(metav1.ConditionFalse, 2), currentHash: "hash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failureHistory(tt.identity, tt.currentHash); len(got) != tt.want {
+				t.Fatalf("failureHistory() = %v, want %d targets", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetsInBackoff(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 11, 0, 0, 0, time.UTC)
+	later, earlier := metav1.NewTime(now.Add(time.Minute)), metav1.NewTime(now.Add(-time.Minute))
+
+	got := targetsInBackoff([]v1alpha1.TargetStatus{
+		{Namespace: "waiting", State: v1alpha1.TargetStateFailed, NextRetryTime: &later},
+		{Namespace: "due", State: v1alpha1.TargetStateFailed, NextRetryTime: &earlier},
+		{Namespace: "unscheduled", State: v1alpha1.TargetStateFailed},
+		{Namespace: "synced", State: v1alpha1.TargetStateSynced, NextRetryTime: &later},
+	}, now)
+
+	if len(got) != 1 || got["waiting"].Namespace != "waiting" {
+		t.Fatalf("targetsInBackoff() = %v, want only the waiting namespace", got)
+	}
+}
+
+func TestScheduleRetries(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 11, 0, 0, 0, time.UTC)
+	waitingUntil := metav1.NewTime(now.Add(5 * time.Minute))
+	policy := Policy{TransientDelay: time.Minute, PermanentDelay: 10 * time.Minute, MaxBackoff: time.Hour}
+	history := []v1alpha1.TargetStatus{
+		{Namespace: "forbidden", State: v1alpha1.TargetStateFailed, ConsecutiveFailures: 2},
+		{
+			Namespace: "waiting", State: v1alpha1.TargetStateFailed, Reason: string(errclass.ReasonTimeout),
+			ConsecutiveFailures: 1, NextRetryTime: &waitingUntil,
+		},
+	}
+
+	obs := NewObservation(4, 10)
+	obs.ObserveFailure("forbidden", errclass.KindConfig, errclass.ReasonForbidden, errors.New("forbidden"))
+	obs.ObserveFailure("timeout", errclass.KindTransient, errclass.ReasonTimeout, errors.New("timeout"))
+	obs.ObserveBackoff(history[1])
+	obs.ObserveSuccess("healthy")
+	obs.Sort()
+	policy.scheduleRetries(obs, history, now)
+
+	want := map[string]struct {
+		failures int32
+		retryAt  time.Time
+	}{
+		"forbidden": {failures: 3, retryAt: now.Add(40 * time.Minute)},
+		"timeout":   {failures: 1, retryAt: now.Add(time.Minute)},
+		"waiting":   {failures: 1, retryAt: waitingUntil.Time},
+	}
+	for _, res := range obs.Results {
+		w, ok := want[res.Namespace]
+		if !ok {
+			if res.Failures != 0 || !res.RetryAt.IsZero() {
+				t.Fatalf("%s scheduled at %s, want no retry", res.Namespace, res.RetryAt)
+			}
+			continue
+		}
+		if res.Failures != w.failures || !res.RetryAt.Equal(w.retryAt) {
+			t.Fatalf("%s = %d failures due %s, want %d due %s",
+				res.Namespace, res.Failures, res.RetryAt, w.failures, w.retryAt)
+		}
+	}
+	if obs.RetryAfter != time.Minute {
+		t.Fatalf("RetryAfter = %s, want the earliest 1m", obs.RetryAfter)
+	}
+	if dec := policy.Decide(obs); dec.Outcome != result.OutcomePartial || dec.RequeueAfter != time.Minute {
+		t.Fatalf("Decide() = %+v, want a partial outcome requeued after 1m", dec)
+	}
+}
+
+func TestObserveBackoff(t *testing.T) {
+	retryAt := metav1.NewTime(time.Date(2026, time.January, 2, 11, 5, 0, 0, time.UTC))
+	obs := NewObservation(2, 10)
+	obs.ObserveBackoff(v1alpha1.TargetStatus{
+		Namespace: "waiting", State: v1alpha1.TargetStateFailed, Reason: string(errclass.ReasonForbidden),
+		ConsecutiveFailures: 2, NextRetryTime: &retryAt,
+	})
+	obs.ObserveSuccess("healthy")
+
+	if obs.Failed != 0 || len(obs.Reasons) != 0 || len(obs.Samples) != 0 {
+		t.Fatalf("failed = %d, reasons = %v, samples = %v, want a namespace in backoff not counted as a failure",
+			obs.Failed, obs.Reasons, obs.Samples)
+	}
+	if obs.BackedOff != 1 {
+		t.Fatalf("BackedOff = %d, want 1", obs.BackedOff)
+	}
+	dec := Policy{PermanentDelay: time.Minute}.Decide(obs)
+	if dec.Outcome != result.OutcomePartial || dec.Reason != result.ReasonForbidden {
+		t.Fatalf("Decide() = %+v, want a partial outcome with the last reason", dec)
+	}
+}
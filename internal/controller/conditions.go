@@ -28,6 +28,38 @@ func markSecretNotFound(cs *status.ConditionSet, message string) {
 	cs.Set(string(v1alpha1.ConditionReferenceSecretReady), metav1.ConditionFalse, string(v1alpha1.ReasonSecretNotFound), message)
 }
 
+func markTargetConflict(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionTargetConflict), metav1.ConditionTrue, string(v1alpha1.ReasonTargetConflict), message)
+}
+
+func markNoTargetConflict(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionTargetConflict), metav1.ConditionFalse, string(v1alpha1.ReasonNoConflict), message)
+}
+
+func markSuspended(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionSuspended), metav1.ConditionTrue, string(v1alpha1.ReasonSuspended), message)
+}
+
+func markNotSuspended(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionSuspended), metav1.ConditionFalse, string(v1alpha1.ReasonActive), message)
+}
+
+func markPaused(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionPaused), metav1.ConditionTrue, string(v1alpha1.ReasonPaused), message)
+}
+
+func markNotPaused(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionPaused), metav1.ConditionFalse, string(v1alpha1.ReasonActive), message)
+}
+
+func markDryRun(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionDryRun), metav1.ConditionTrue, string(v1alpha1.ReasonDryRun), message)
+}
+
+func markNoDryRun(cs *status.ConditionSet, message string) {
+	cs.Set(string(v1alpha1.ConditionDryRun), metav1.ConditionFalse, string(v1alpha1.ReasonActive), message)
+}
+
 func markSecretGetFailed(cs *status.ConditionSet, message string) {
 	cs.Set(string(v1alpha1.ConditionReferenceSecretReady), metav1.ConditionFalse, string(v1alpha1.ReasonSecretGetFailed), message)
 }
@@ -6,24 +6,33 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
 	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
 	"github.com/lapacek-labs/identity-operator/pkg/logging"
 	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/observability/noop"
 	"github.com/lapacek-labs/identity-operator/pkg/result"
 	"github.com/lapacek-labs/identity-operator/pkg/status"
 )
@@ -37,7 +46,31 @@ type reconcileContext struct {
 	identity    *v1alpha1.IdentitySyncPolicy
 	conditions  *status.ConditionSet
 	observation *Observation
-	currentHash string
+	// sources are the source Secrets that could be read; missingSources are
+	// the target names of the ones that were not found.
+	sources        []source
+	missingSources []string
+	currentHash    string
+	targetsHash    string
+	// dryRun marks a fan-out that wrote nothing; its results only feed status.dryRun.
+	dryRun bool
+}
+
+// Options are operator-wide controller settings.
+type Options struct {
+	// FanoutParallelism bounds concurrent target namespace reconciles per policy.
+	// spec.fanoutParallelism overrides it; zero uses the configuration file.
+	FanoutParallelism int
+	// Config holds the tuning from the configuration file. Nil uses the defaults.
+	Config *config.Store
+	// ProtectedNamespaces are never created nor written into, whatever the
+	// policy selects.
+	ProtectedNamespaces guard.Namespaces
+	// PauseConfigMap is the ConfigMap holding the operator-wide pause switch.
+	// An empty name disables the switch.
+	PauseConfigMap types.NamespacedName
+	// DryRun runs every policy as if spec.dryRun was set.
+	DryRun bool
 }
 
 // Controller reconciles a IdentitySyncPolicy object.
@@ -46,10 +79,24 @@ type Controller struct {
 	scheme  *runtime.Scheme
 	limiter *logging.Limiter
 	metrics observability.Recorder
+	tracer  observability.Tracer
+	events  record.EventRecorder
+	options Options
 }
 
-func NewController(cl client.Client, sch *runtime.Scheme, lim *logging.Limiter, rec observability.Recorder) *Controller {
-	return &Controller{client: cl, scheme: sch, limiter: lim, metrics: rec}
+func NewController(
+	cl client.Client,
+	sch *runtime.Scheme,
+	lim *logging.Limiter,
+	rec observability.Recorder,
+	tr observability.Tracer,
+	ev record.EventRecorder,
+	opts Options,
+) *Controller {
+	if tr == nil {
+		tr = noop.Tracer{}
+	}
+	return &Controller{client: cl, scheme: sch, limiter: lim, metrics: rec, tracer: tr, events: ev, options: opts}
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -57,24 +104,56 @@ func (c *Controller) SetupWithManager(mgr controllerruntime.Manager) error {
 	if err := setupIndexers(mgr); err != nil {
 		return err
 	}
-	return controllerruntime.NewControllerManagedBy(mgr).
+	bldr := controllerruntime.NewControllerManagedBy(mgr).
 		For(&v1alpha1.IdentitySyncPolicy{}).
 		Named("identity-sync-policy").
+		WithOptions(crcontroller.Options{
+			MaxConcurrentReconciles: c.tuning().Reconcile.MaxConcurrentReconciles,
+		}).
+		Owns(&corev1.Secret{}, builder.WithPredicates(managedTargetChanged())).
+		Owns(&corev1.ServiceAccount{}, builder.WithPredicates(managedTargetChanged())).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(c.mapRequestToIdentity),
 			builder.WithPredicates(sourceSecretDataChanged()),
 		).
-		Complete(c)
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(c.mapNamespaceToIdentity),
+			builder.WithPredicates(namespaceChanged()),
+		)
+	if c.options.PauseConfigMap.Name != "" {
+		bldr = bldr.Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(c.mapPauseToIdentities),
+			builder.WithPredicates(isPauseConfigMap(c.options.PauseConfigMap)),
+		)
+	}
+	return bldr.Complete(c)
 }
 
-// +kubebuilder:rbac:groups=identity.lapacek-labs.org,resources=identitysyncpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=identity.lapacek-labs.org,resources=identitysyncpolicies,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=identity.lapacek-labs.org,resources=identitysyncpolicies/status,verbs=get;patch;update
 // +kubebuilder:rbac:groups=identity.lapacek-labs.org,resources=identitysyncpolicies/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=list;get;watch;create;patch;update
+// +kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=list;get;watch;create;patch;update;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is syncing service accounts and secrets in target namespaces.
 func (c *Controller) Reconcile(ctx context.Context, req controllerruntime.Request) (controllerruntime.Result, error) {
+	ctx, span := c.tracer.Start(ctx, observability.SpanReconcile,
+		observability.String(observability.AttrPolicy, req.String()))
+	defer span.End()
+
+	res, err := c.reconcile(ctx, req)
+	if err != nil {
+		span.Fail(err, errorAttributes(err)...)
+	}
+	return res, err
+}
+
+func (c *Controller) reconcile(ctx context.Context, req controllerruntime.Request) (controllerruntime.Result, error) {
 	logger := logf.FromContext(ctx).WithValues(
 		"controller", ID,
 		"operation", observability.OpReconcile,
@@ -82,40 +161,64 @@ func (c *Controller) Reconcile(ctx context.Context, req controllerruntime.Reques
 	)
 	ctx = logf.IntoContext(ctx, logger)
 	startTime := time.Now()
+	cfg := c.tuning()
 
 	identity := &v1alpha1.IdentitySyncPolicy{}
 	err := c.client.Get(ctx, req.NamespacedName, identity)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			if c.metrics != nil {
+				c.metrics.ForgetPolicy(req.String())
+			}
 			return controllerruntime.Result{}, nil
 		}
 		return controllerruntime.Result{}, err
 	}
 
 	conditionSet := status.NewConditionSet(identity.Status.Conditions, identity.GetGeneration(), startTime)
+	retry := NewPolicy(cfg.Retry).WithRetryPolicy(identity.Spec.RetryPolicy)
 
-	key := types.NamespacedName{
-		Name:      identity.Spec.Secret.SourceRef.Name,
-		Namespace: identity.Spec.Secret.SourceRef.Namespace,
+	isPaused, err := paused(ctx, c.client, c.options.PauseConfigMap)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	if c.metrics != nil {
+		c.metrics.RecordPaused(isPaused)
+	}
+	if isPaused {
+		return c.finish(ctx, reconcileContext{
+			phase:      observability.PhasePause,
+			identity:   identity,
+			conditions: conditionSet,
+			decision: result.Decision{
+				Outcome: result.OutcomeSkipped,
+				Msg:     "operator paused",
+			},
+			start: startTime,
+		})
+	}
+
+	if !identity.DeletionTimestamp.IsZero() {
+		return c.finalize(ctx, identity, conditionSet, startTime)
+	}
+	if identity.Spec.Suspend {
+		return c.finish(ctx, reconcileContext{
+			phase:      observability.PhaseSuspend,
+			identity:   identity,
+			conditions: conditionSet,
+			decision: result.Decision{
+				Outcome: result.OutcomeSkipped,
+				Msg:     "reconciliation suspended",
+			},
+			start: startTime,
+		})
+	}
+	if err := c.ensureFinalizer(ctx, identity); err != nil {
+		return controllerruntime.Result{}, err
 	}
-	secret := &corev1.Secret{}
-	secretErr := c.client.Get(ctx, key, secret)
-	if secretErr != nil {
-		if apierrors.IsNotFound(secretErr) {
-			return c.finish(ctx, reconcileContext{
-				phase:      observability.PhasePrecondition,
-				identity:   identity,
-				conditions: conditionSet,
-				decision: result.Decision{
-					Outcome:      result.OutcomeFailed,
-					Reason:       result.ReasonNotFound,
-					RequeueAfter: 5 * time.Minute,
-					Msg:          "reference secret not found",
-				},
-				start: startTime,
-			})
-		}
 
+	sources, missingSources, secretErr := c.loadSources(ctx, identity)
+	if secretErr != nil {
 		_, errReason := errclass.ClassifyError(secretErr, errclass.NotFoundAsTransient)
 		reason := mapErrReasonToResultReason(errReason)
 
@@ -132,32 +235,116 @@ func (c *Controller) Reconcile(ctx context.Context, req controllerruntime.Reques
 			start: startTime,
 		})
 	}
-	currentSecretHash := secretDataHash(secret)
-
-	if shouldFastPath(identity, currentSecretHash) {
+	if len(sources) == 0 && len(missingSources) > 0 {
+		return c.finish(ctx, reconcileContext{
+			phase:          observability.PhasePrecondition,
+			identity:       identity,
+			conditions:     conditionSet,
+			missingSources: missingSources,
+			decision: result.Decision{
+				Outcome:      result.OutcomeFailed,
+				Reason:       result.ReasonNotFound,
+				RequeueAfter: cfg.Retry.MissingSourceDelay.Duration,
+				Msg:          "reference secret not found",
+			},
+			start: startTime,
+		})
+	}
+	currentSecretHash := sourcesFingerprint(sources)
+
+	targetNamespaces, sourceNamespaces, targetsErr := resolveTargetNamespaces(ctx, c.client, identity)
+	if targetsErr != nil {
+		kind, errReason := errclass.ClassifyError(targetsErr, errclass.NotFoundAsTransient)
+		decision := result.Decision{
+			Outcome: result.OutcomeFailed,
+			Reason:  mapErrReasonToResultReason(errReason),
+			Msg:     "failed resolving target namespaces",
+		}
+		if kind == errclass.KindConfig {
+			decision.RequeueAfter = retry.Delay(false)
+		} else {
+			decision.Err = targetsErr
+		}
+		return c.finish(ctx, reconcileContext{
+			phase:          observability.PhaseTargets,
+			identity:       identity,
+			conditions:     conditionSet,
+			sources:        sources,
+			missingSources: missingSources,
+			currentHash:    currentSecretHash,
+			decision:       decision,
+			start:          startTime,
+		})
+	}
+	currentTargetsHash := targetsHash(targetNamespaces)
+	c.tracer.SpanFromContext(ctx).SetAttributes(observability.Int(observability.AttrTargets, len(targetNamespaces)))
+
+	dryRun := c.options.DryRun || identity.Spec.DryRun
+	if !dryRun && shouldFastPath(identity, currentSecretHash, currentTargetsHash) &&
+		targetsInSync(ctx, c.client, identity, targetNamespaces, sources) {
+		c.tracer.SpanFromContext(ctx).SetAttributes(observability.Bool(observability.AttrFastPath, true))
+		// The gauges start empty after a restart; an in-sync policy may never
+		// reach finish again.
+		if c.metrics != nil {
+			c.metrics.RecordPolicy(policyMetrics(identity))
+		}
 		return controllerruntime.Result{}, nil
 	}
 
-	observation := reconcileIdentity(ctx, c.scheme, c.client, identity, secret)
-	decision := DefaultPolicy().Decide(observation)
-
-	switch decision.Outcome {
-	case result.OutcomeSuccess:
+	parallelism := c.options.FanoutParallelism
+	if parallelism <= 0 {
+		parallelism = cfg.Fanout.Parallelism
+	}
+	if identity.Spec.FanoutParallelism != nil {
+		parallelism = int(*identity.Spec.FanoutParallelism)
+	}
+	// A dry run reports every namespace, so it ignores backoff.
+	var history []v1alpha1.TargetStatus
+	if !dryRun {
+		history = failureHistory(identity, currentSecretHash)
+	}
+	observation := reconcileIdentity(ctx, c.scheme, c.client, identity, targetNamespaces, sources, fanoutOptions{
+		parallelism: parallelism,
+		protected:   c.options.ProtectedNamespaces,
+		dryRun:      dryRun,
+		tracer:      c.tracer,
+		maxSamples:  cfg.Fanout.MaxSamples,
+		backoff:     targetsInBackoff(history, startTime),
+
+		sourceNamespaces: sourceNamespaces,
+	})
+	if !dryRun {
+		retry.scheduleRetries(observation, history, time.Now())
+	}
+	decision := retry.Decide(observation)
+
+	switch {
+	case len(missingSources) > 0 && decision.Outcome == result.OutcomeSuccess:
+		// Every available source was synced; the missing ones still degrade the policy.
+		decision.Outcome = result.OutcomePartial
+		decision.Reason = result.ReasonNotFound
+		decision.RequeueAfter = cfg.Retry.MissingSourceDelay.Duration
+		decision.Msg = "reference secret not found"
+	case decision.Outcome == result.OutcomeSuccess:
 		decision.Msg = "fanout completed"
-	case result.OutcomePartial:
+	case decision.Outcome == result.OutcomePartial:
 		decision.Msg = "partial fanout failure"
-	case result.OutcomeFailed:
+	case decision.Outcome == result.OutcomeFailed:
 		decision.Msg = "fanout failed"
 	}
 
 	return c.finish(ctx, reconcileContext{
-		phase:       observability.PhaseFanout,
-		identity:    identity,
-		conditions:  conditionSet,
-		currentHash: currentSecretHash,
-		observation: observation,
-		decision:    decision,
-		start:       startTime,
+		phase:          observability.PhaseFanout,
+		identity:       identity,
+		conditions:     conditionSet,
+		sources:        sources,
+		missingSources: missingSources,
+		currentHash:    currentSecretHash,
+		targetsHash:    currentTargetsHash,
+		observation:    observation,
+		decision:       decision,
+		start:          startTime,
+		dryRun:         dryRun,
 	})
 }
 
@@ -168,23 +355,63 @@ func (c *Controller) finish(ctx context.Context, f reconcileContext) (controller
 	if f.identity == nil {
 		return controllerruntime.Result{}, nil
 	}
+	c.tracer.SpanFromContext(ctx).SetAttributes(
+		observability.String(observability.AttrPhase, string(f.phase)),
+		observability.String(observability.AttrOutcome, string(f.decision.Outcome)),
+		observability.String(observability.AttrReason, string(f.decision.Reason)),
+	)
 
 	if f.conditions != nil {
 		// --- PRECONDITION -> ReferenceSecretReady (single writer) ---
 		switch f.phase {
 		case observability.PhasePrecondition:
 			if f.decision.Reason == result.ReasonNotFound {
-				markSecretNotFound(f.conditions, "Reference secret not found")
+				markSecretNotFound(f.conditions, missingSourcesMessage(f.missingSources))
 			} else {
 				markSecretGetFailed(f.conditions, "Reference secret get failed")
 			}
-		case observability.PhaseFanout:
-			markSecretAvailable(f.conditions, "Reference secret available")
+		case observability.PhaseTargets, observability.PhaseFanout:
+			if len(f.missingSources) > 0 {
+				markSecretNotFound(f.conditions, missingSourcesMessage(f.missingSources))
+			} else {
+				markSecretAvailable(f.conditions, "Reference secret available")
+			}
+		}
+
+		// --- FANOUT -> TargetConflict ---
+		if f.observation != nil && f.phase == observability.PhaseFanout {
+			if namespaces := f.observation.ConflictNamespaces(); len(namespaces) > 0 {
+				markTargetConflict(f.conditions, "Unmanaged target objects in namespaces: "+strings.Join(namespaces, ", "))
+			} else {
+				markNoTargetConflict(f.conditions, "No target conflicts")
+			}
+		}
+
+		// --- SUSPEND/PAUSE -> Suspended/Paused ---
+		if f.identity.Spec.Suspend {
+			markSuspended(f.conditions, "Reconciliation suspended by spec.suspend")
+		} else {
+			markNotSuspended(f.conditions, "Reconciliation active")
+		}
+		if f.phase == observability.PhasePause {
+			markPaused(f.conditions, "All writes stopped by the operator pause switch")
+		} else {
+			markNotPaused(f.conditions, "Operator writes enabled")
+		}
+		if f.phase == observability.PhaseFanout {
+			if f.dryRun {
+				markDryRun(f.conditions, "Changes are reported in status.dryRun and not written")
+			} else {
+				markNoDryRun(f.conditions, "Changes are written")
+			}
 		}
 
 		// --- GLOBAL outcome -> Ready/Degraded ---
-		switch f.decision.Outcome {
-		case result.OutcomeSuccess:
+		switch {
+		case f.decision.Outcome == result.OutcomeSkipped, f.dryRun:
+			// Ready/Degraded keep describing the targets as last reconciled; a
+			// dry run wrote nothing and is reported by DryRun and status.dryRun.
+		case f.decision.Outcome == result.OutcomeSuccess:
 			markReady(f.conditions, "Reconcile completed")
 		default:
 			msg := f.decision.Msg
@@ -195,17 +422,56 @@ func (c *Controller) finish(ctx context.Context, f reconcileContext) (controller
 		}
 	}
 
-	desiredHash := ""
-	if f.decision.Outcome == result.OutcomeSuccess {
-		desiredHash = f.currentHash
+	// A dry run must not record anything as synced, or the fast path would skip
+	// the writes once it is turned off.
+	synced := f.decision.Outcome == result.OutcomeSuccess && !f.dryRun
+	desired := statusFields{}
+	if synced {
+		desired.sourceHash = f.currentHash
+		desired.targetsHash = f.targetsHash
+	}
+	if f.sources != nil || f.missingSources != nil {
+		desired.secrets = buildSecretStatuses(
+			f.identity.Status.Secrets,
+			f.sources,
+			f.missingSources,
+			synced,
+		)
+	}
+	if f.observation != nil && f.phase == observability.PhaseFanout {
+		if f.dryRun {
+			// status.targets keeps describing what was last written.
+			desired.dryRun = &dryRunSummary{status: buildDryRunStatus(f.observation.Planned)}
+		} else {
+			desired.dryRun = &dryRunSummary{}
+			pruned := int32(f.observation.Pruned)
+			desired.prunedTargets = &pruned
+			desired.targets = buildTargetsSummary(
+				f.identity.Status.Targets,
+				f.observation,
+				f.currentHash,
+				metav1.NewTime(f.start),
+				c.tuning().Fanout.MaxTargetStatuses,
+			)
+		}
+	}
+	var latencies []time.Duration
+	if synced && f.phase == observability.PhaseFanout {
+		latencies = propagationLatencies(f.identity, f.sources, time.Now())
+	}
+	if f.currentHash != "" && !f.dryRun {
+		propagation := trackPropagation(f.identity.Status, f.currentHash, synced, f.start, time.Now())
+		desired.propagation = &propagation
 	}
 	statusPatched := false
 	if f.conditions != nil {
-		patched, err := c.patchStatusIfChanged(ctx, f.identity, f.conditions, desiredHash)
+		prevTargets := f.identity.Status.Targets
+		patched, err := c.patchStatusIfChanged(ctx, f.identity, f.conditions, desired)
 		if err != nil {
 			return controllerruntime.Result{}, err
 		}
 		statusPatched = patched
+		c.recordEvents(f, prevTargets)
 	}
 
 	if c.metrics != nil {
@@ -215,18 +481,33 @@ func (c *Controller) finish(ctx context.Context, f reconcileContext) (controller
 			Phase:   f.phase,
 		}, time.Since(f.start))
 
-		if f.observation != nil {
+		if f.observation != nil && f.phase == observability.PhaseFanout {
 			c.metrics.RecordFanout(observability.Fanout{
 				Total:   f.observation.Total,
 				Success: f.observation.Success,
 				Failed:  f.observation.Failed,
+				Pruned:  f.observation.Pruned,
+
+				FailureReasons: f.observation.Reasons,
 			})
 		}
+		for _, latency := range latencies {
+			c.metrics.RecordPropagation(latency)
+		}
+		if desired.propagation != nil && desired.propagation.last != nil {
+			c.metrics.RecordPropagationCompleted(desired.propagation.last.Duration.Duration)
+		}
+		if f.phase == observability.PhaseFinalize && !controllerutil.ContainsFinalizer(f.identity, Finalizer) {
+			c.metrics.ForgetPolicy(client.ObjectKeyFromObject(f.identity).String())
+		} else {
+			c.metrics.RecordPolicy(policyMetrics(f.identity))
+		}
 	}
 
 	logOperationIfAllowed(
 		ctx,
 		c.limiter,
+		c.tuning().Logging,
 		f.phase,
 		f.identity,
 		f.decision,
@@ -237,25 +518,69 @@ func (c *Controller) finish(ctx context.Context, f reconcileContext) (controller
 	return f.decision.Result()
 }
 
+// statusFields holds the non-condition status fields a reconcile wants to persist.
+// Zero values mean "keep the current value".
+type statusFields struct {
+	sourceHash    string
+	targetsHash   string
+	secrets       []v1alpha1.SecretStatus
+	prunedTargets *int32
+	targets       *targetsSummary
+	dryRun        *dryRunSummary
+	propagation   *propagationSummary
+}
+
+// dryRunSummary replaces status.dryRun; a nil status clears it.
+type dryRunSummary struct {
+	status *v1alpha1.DryRunStatus
+}
+
+func (f statusFields) applyTo(st *v1alpha1.IdentitySyncPolicyStatus) {
+	if f.sourceHash != "" {
+		st.ObservedSourceSecretHash = f.sourceHash
+	}
+	if f.targetsHash != "" {
+		st.ObservedTargetsHash = f.targetsHash
+	}
+	if f.secrets != nil {
+		st.Secrets = f.secrets
+	}
+	if f.prunedTargets != nil {
+		st.PrunedTargets = *f.prunedTargets
+	}
+	if f.targets != nil {
+		f.targets.applyTo(st)
+	}
+	if f.dryRun != nil {
+		st.DryRun = f.dryRun.status
+	}
+	if f.propagation != nil {
+		f.propagation.applyTo(st)
+	}
+}
+
 func (c *Controller) patchStatusIfChanged(
 	ctx context.Context,
 	identity *v1alpha1.IdentitySyncPolicy,
 	cs *status.ConditionSet,
-	desiredHash string,
+	desired statusFields,
 ) (bool, error) {
+	ctx, span := c.tracer.Start(ctx, observability.SpanPatchStatus,
+		observability.String(observability.AttrPolicy, client.ObjectKeyFromObject(identity).String()))
+	defer span.End()
 
 	condChanged := cs != nil && cs.Changed()
 
-	hashChanged := desiredHash != "" && identity.Status.ObservedSourceSecretHash != desiredHash
+	next := identity.Status.DeepCopy()
+	desired.applyTo(next)
+	fieldsChanged := !equality.Semantic.DeepEqual(&identity.Status, next)
 
-	if !condChanged && !hashChanged {
+	if !condChanged && !fieldsChanged {
 		return false, nil
 	}
 	base := identity.DeepCopy()
 
-	if hashChanged {
-		identity.Status.ObservedSourceSecretHash = desiredHash
-	}
+	desired.applyTo(&identity.Status)
 	if cs != nil {
 		for _, condition := range cs.Conditions() {
 			meta.SetStatusCondition(&identity.Status.Conditions, condition)
@@ -264,11 +589,59 @@ func (c *Controller) patchStatusIfChanged(
 
 	if err := c.client.Status().Patch(ctx, identity, client.MergeFrom(base)); err != nil {
 		kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+		span.Fail(err, errorAttributes(err)...)
 		return false, fmt.Errorf("status patch failed (%s/%s): %w", kind, reason, err)
 	}
+	span.SetAttributes(observability.Bool(observability.AttrPatched, true))
 	return true, nil
 }
 
+// loadSources reads the source Secrets inside their own span.
+func (c *Controller) loadSources(
+	ctx context.Context,
+	identity *v1alpha1.IdentitySyncPolicy,
+) ([]source, []string, error) {
+	ctx, span := c.tracer.Start(ctx, observability.SpanLoadSources,
+		observability.String(observability.AttrPolicy, client.ObjectKeyFromObject(identity).String()))
+	defer span.End()
+
+	sources, missing, err := loadSources(ctx, c.client, identity)
+	if err != nil {
+		span.Fail(err, errorAttributes(err)...)
+		return sources, missing, err
+	}
+	span.SetAttributes(
+		observability.Int(observability.AttrSources, len(sources)),
+		observability.Int(observability.AttrMissingSources, len(missing)),
+	)
+	return sources, missing, nil
+}
+
+// errorAttributes describes err by its errclass kind and reason.
+func errorAttributes(err error) []observability.Attribute {
+	kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+	return []observability.Attribute{
+		observability.String(observability.AttrErrorKind, string(kind)),
+		observability.String(observability.AttrErrorReason, string(reason)),
+	}
+}
+
+// tuning returns the current operator configuration.
+func (c *Controller) tuning() config.OperatorConfig {
+	if c.options.Config == nil {
+		return config.Default()
+	}
+	return c.options.Config.Get()
+}
+
 func (c *Controller) mapRequestToIdentity(ctx context.Context, obj client.Object) []reconcile.Request {
 	return mapRequestToIdentity(ctx, c.client, obj)
 }
+
+func (c *Controller) mapNamespaceToIdentity(ctx context.Context, obj client.Object) []reconcile.Request {
+	return mapNamespaceToIdentity(ctx, c.client, obj)
+}
+
+func (c *Controller) mapPauseToIdentities(ctx context.Context, obj client.Object) []reconcile.Request {
+	return mapPauseToIdentities(ctx, c.client, obj)
+}
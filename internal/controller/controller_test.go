@@ -13,6 +13,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +32,8 @@ type identityFixture struct {
 	tokenName           string
 	tokenValue          string
 	targetNamespaces    []string
+	conflictPolicy      v1alpha1.ConflictPolicy
+	dryRun              bool
 }
 
 var _ = Describe("IdentitySyncPolicy Controller", func() {
@@ -95,6 +99,56 @@ var _ = Describe("IdentitySyncPolicy Controller", func() {
 			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
 		})
 
+		It("reports per-target status", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+				g.Expect(identity.Status.Desired).To(BeEquivalentTo(len(testData.targetNamespaces)))
+				g.Expect(identity.Status.Synced).To(BeEquivalentTo(len(testData.targetNamespaces)))
+				g.Expect(identity.Status.Failed).To(BeZero())
+				g.Expect(identity.Status.Targets).To(HaveLen(len(testData.targetNamespaces)))
+				for _, target := range identity.Status.Targets {
+					g.Expect(target.State).To(Equal(v1alpha1.TargetStateSynced))
+					g.Expect(target.SyncedHash).To(Equal(identity.Status.ObservedSourceSecretHash))
+					g.Expect(target.LastSyncTime).NotTo(BeNil())
+				}
+				g.Expect(identity.Status.TargetsSourceHash).To(Equal(identity.Status.ObservedSourceSecretHash))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("prunes target objects when a namespace leaves spec.targetNamespaces", func() {
+			removedNs := testData.targetNamespaces[0]
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: removedNs}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				if err := k8sClient.Get(ctx, key, identity); err != nil {
+					return err
+				}
+				identity.Spec.TargetNamespaces = testData.targetNamespaces[1:]
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+				return apierrors.IsNotFound(err)
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+
+			saKey := types.NamespacedName{Name: testData.serviceAccountName, Namespace: removedNs}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, saKey, &corev1.ServiceAccount{})
+				return apierrors.IsNotFound(err)
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+
+			keptKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[1]}
+			Expect(k8sClient.Get(ctx, keptKey, &corev1.Secret{})).To(Succeed())
+		})
+
 		It("does not recreate target secret when nothing changes", func() {
 			var initialRV string
 			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
@@ -117,6 +171,49 @@ var _ = Describe("IdentitySyncPolicy Controller", func() {
 			}, 2*time.Second, 200*time.Millisecond).Should(Equal(initialRV))
 		})
 
+		It("restores a target Secret edited outside the operator", func() {
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Eventually(func() string {
+				s := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, s)
+				return string(s.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+
+			Eventually(func() error {
+				s := &corev1.Secret{}
+				if err := k8sClient.Get(ctx, secretKey, s); err != nil {
+					return err
+				}
+				s.Data[testData.tokenName] = []byte("t4mp3r3d")
+				return k8sClient.Update(ctx, s)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			Eventually(func() string {
+				s := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, s)
+				return string(s.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+		})
+
+		It("recreates a deleted target Secret", func() {
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			target := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, target)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			deletedUID := target.UID
+
+			Expect(k8sClient.Delete(ctx, target)).To(Succeed())
+
+			Eventually(func() bool {
+				s := &corev1.Secret{}
+				if err := k8sClient.Get(ctx, secretKey, s); err != nil {
+					return false
+				}
+				return s.UID != deletedUID && string(s.Data[testData.tokenName]) == testData.tokenValue
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+		})
+
 		It("updates target Secret when source Secret changes", func() {
 			Eventually(func() error {
 				source := &corev1.Secret{
@@ -146,6 +243,672 @@ var _ = Describe("IdentitySyncPolicy Controller", func() {
 					return string(target.Data[testData.tokenName])
 				}, 5*time.Second, 100*time.Millisecond).Should(Equal("R3G3N3R8T3D"))
 			}
+
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+				g.Expect(identity.Status.PendingPropagation).To(BeNil())
+				g.Expect(identity.Status.LastPropagation).NotTo(BeNil())
+				g.Expect(identity.Status.LastPropagation.SourceHash).To(Equal(identity.Status.ObservedSourceSecretHash))
+				g.Expect(identity.Status.LastPropagation.Duration).NotTo(BeNil())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("keeps fields other managers set on target ServiceAccounts", func() {
+			namespace := testData.targetNamespaces[0]
+			saKey := types.NamespacedName{Name: testData.serviceAccountName, Namespace: namespace}
+			Eventually(func() error {
+				sa := &corev1.ServiceAccount{}
+				if err := k8sClient.Get(ctx, saKey, sa); err != nil {
+					return err
+				}
+				sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: "registry"})
+				return k8sClient.Update(ctx, sa, client.FieldOwner("pull-secret-patcher"))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			// Force a full fan-out by changing the source.
+			Eventually(func() error {
+				source := &corev1.Secret{}
+				key := types.NamespacedName{Name: testData.sourceSecretName, Namespace: testData.sourceNamespaceName}
+				if err := k8sClient.Get(ctx, key, source); err != nil {
+					return err
+				}
+				source.Data[testData.tokenName] = []byte("r0t4t3d")
+				return k8sClient.Update(ctx, source)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: namespace}
+			Eventually(func() string {
+				target := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, target)
+				return string(target.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal("r0t4t3d"))
+
+			sa := &corev1.ServiceAccount{}
+			Expect(k8sClient.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: "registry"}))
+			Expect(sa.Labels).To(HaveKeyWithValue(LabelPolicyName, testData.identityName))
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller namespaceSelector", func() {
+	Context("with namespaces selected by label", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		selectorLabel := map[string]string{}
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			selectorLabel = map[string]string{"identity-sync-test": uniqueStr("tenant")}
+			seedIdentityFixture(testData)
+
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				if err := k8sClient.Get(ctx, key, identity); err != nil {
+					return err
+				}
+				identity.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: selectorLabel}
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("syncs into a namespace created with matching labels", func() {
+			selectedNs := uniqueStr("selected")
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: selectedNs, Labels: selectorLabel},
+			}
+			Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: selectedNs}
+			Eventually(func() string {
+				s := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, s)
+				return string(s.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+		})
+
+		It("syncs into an existing namespace once it is relabelled", func() {
+			relabelledNs := uniqueStr("relabelled")
+			Expect(createNamespace(ctx, relabelledNs, k8sClient)).To(Succeed())
+
+			Eventually(func() error {
+				namespace := &corev1.Namespace{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: relabelledNs}, namespace); err != nil {
+					return err
+				}
+				namespace.Labels = selectorLabel
+				return k8sClient.Update(ctx, namespace)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			saKey := types.NamespacedName{Name: testData.serviceAccountName, Namespace: relabelledNs}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, saKey, &corev1.ServiceAccount{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller unavailable namespaces", func() {
+	Context("with a target namespace that does not exist yet", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		pendingNs := ""
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+
+			pendingNs = uniqueStr("pending")
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				if err := k8sClient.Get(ctx, key, identity); err != nil {
+					return err
+				}
+				identity.Spec.TargetNamespaces = append(identity.Spec.TargetNamespaces, pendingNs)
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("reports the namespace as missing without degrading the policy", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeTrue())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionDegraded))).To(BeFalse())
+				g.Expect(identity.Status.Targets).To(ContainElement(SatisfyAll(
+					HaveField("Namespace", pendingNs),
+					HaveField("State", v1alpha1.TargetStateNamespaceMissing),
+				)))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("reports a terminating namespace without degrading the policy", func() {
+			terminatingNs := testData.targetNamespaces[0]
+			Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: terminatingNs}})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeTrue())
+				g.Expect(identity.Status.Targets).To(ContainElement(SatisfyAll(
+					HaveField("Namespace", terminatingNs),
+					HaveField("State", v1alpha1.TargetStateTerminating),
+					HaveField("Reason", "NamespaceTerminating"),
+				)))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("syncs into the namespace as soon as it is created", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+				g.Expect(identity.Status.Targets).To(ContainElement(HaveField("Namespace", pendingNs)))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			Expect(createNamespace(ctx, pendingNs, k8sClient)).To(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: pendingNs}
+			Eventually(func() string {
+				s := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, s)
+				return string(s.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller secrets", func() {
+	Context("with several source Secrets", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+		tlsSecretName := ""
+		pullSecretName := ""
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+
+			tlsSecretName = uniqueStr("tls")
+			pullSecretName = uniqueStr("pull")
+			tlsData := map[string][]byte{"ca.crt": []byte("ca")}
+			Expect(createSecret(ctx, tlsSecretName, testData.sourceNamespaceName, tlsData, k8sClient)).To(Succeed())
+
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				if err := k8sClient.Get(ctx, identityKey, identity); err != nil {
+					return err
+				}
+				identity.Spec.Secrets = []v1alpha1.Secret{
+					{
+						Name:      tlsSecretName,
+						SourceRef: v1alpha1.NamespacedNameRef{Name: tlsSecretName, Namespace: testData.sourceNamespaceName},
+					},
+					{
+						Name:      pullSecretName,
+						SourceRef: v1alpha1.NamespacedNameRef{Name: pullSecretName, Namespace: testData.sourceNamespaceName},
+					},
+				}
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("syncs available sources and reports the missing one", func() {
+			for _, targetNamespace := range testData.targetNamespaces {
+				Eventually(func() string {
+					s := &corev1.Secret{}
+					_ = k8sClient.Get(ctx, types.NamespacedName{Name: tlsSecretName, Namespace: targetNamespace}, s)
+					return string(s.Data["ca.crt"])
+				}, 5*time.Second, 100*time.Millisecond).Should(Equal("ca"))
+			}
+
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionFalse(identity.Status.Conditions,
+					string(v1alpha1.ConditionReferenceSecretReady))).To(BeTrue())
+				g.Expect(identity.Status.Secrets).To(ContainElements(
+					v1alpha1.SecretStatus{Name: pullSecretName},
+					HaveField("Name", tlsSecretName),
+				))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("syncs a missing source once it is created", func() {
+			pullData := map[string][]byte{".dockerconfigjson": []byte("{}")}
+			Expect(createSecret(ctx, pullSecretName, testData.sourceNamespaceName, pullData, k8sClient)).To(Succeed())
+
+			for _, targetNamespace := range testData.targetNamespaces {
+				Eventually(func() error {
+					return k8sClient.Get(ctx, types.NamespacedName{Name: pullSecretName, Namespace: targetNamespace}, &corev1.Secret{})
+				}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			}
+
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeTrue())
+				g.Expect(identity.Status.Secrets).To(HaveLen(3))
+				for _, secret := range identity.Status.Secrets {
+					g.Expect(secret.Available).To(BeTrue())
+					g.Expect(secret.ObservedHash).NotTo(BeEmpty())
+				}
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller conflictPolicy", func() {
+	Context("with an unmanaged Secret in a target namespace", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		tenantNs := ""
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			testData.conflictPolicy = v1alpha1.ConflictPolicySkip
+			seedIdentityFixture(testData)
+
+			tenantNs = uniqueStr("tenant")
+			Expect(createNamespace(ctx, tenantNs, k8sClient)).To(Succeed())
+			tenantData := map[string][]byte{testData.tokenName: []byte("tenant-owned")}
+			Expect(createSecret(ctx, testData.secretName, tenantNs, tenantData, k8sClient)).To(Succeed())
+
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				if err := k8sClient.Get(ctx, key, identity); err != nil {
+					return err
+				}
+				identity.Spec.TargetNamespaces = append(identity.Spec.TargetNamespaces, tenantNs)
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("skips the namespace and reports the conflict", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				key := types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+				g.Expect(k8sClient.Get(ctx, key, identity)).To(Succeed())
+
+				conflict := meta.FindStatusCondition(identity.Status.Conditions, string(v1alpha1.ConditionTargetConflict))
+				g.Expect(conflict).NotTo(BeNil())
+				g.Expect(conflict.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeTrue())
+
+				g.Expect(identity.Status.Targets).To(ContainElement(SatisfyAll(
+					HaveField("Namespace", tenantNs),
+					HaveField("State", v1alpha1.TargetStateSkipped),
+					HaveField("Reason", "TargetConflict"),
+				)))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testData.secretName, Namespace: tenantNs}, secret)).To(Succeed())
+			Expect(string(secret.Data[testData.tokenName])).To(Equal("tenant-owned"))
+			Expect(secret.OwnerReferences).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller suspend", func() {
+	Context("with a suspended policy", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+
+		setSuspend := func(suspend bool) {
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				if err := k8sClient.Get(ctx, identityKey, identity); err != nil {
+					return err
+				}
+				identity.Spec.Suspend = suspend
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			setSuspend(true)
+		})
+
+		It("stops propagating source changes until resumed", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionSuspended))).To(BeTrue())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			Eventually(func() error {
+				source := &corev1.Secret{}
+				sourceKey := types.NamespacedName{Name: testData.sourceSecretName, Namespace: testData.sourceNamespaceName}
+				if err := k8sClient.Get(ctx, sourceKey, source); err != nil {
+					return err
+				}
+				source.Data[testData.tokenName] = []byte("compromised")
+				return k8sClient.Update(ctx, source)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Consistently(func() string {
+				target := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, target)
+				return string(target.Data[testData.tokenName])
+			}, 2*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+
+			setSuspend(false)
+			Eventually(func() string {
+				target := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, target)
+				return string(target.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal("compromised"))
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller pause switch", func() {
+	Context("with the operator paused", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+
+		setPaused := func(value string) {
+			Eventually(func() error {
+				configMap := &corev1.ConfigMap{}
+				err := k8sClient.Get(ctx, pauseConfigMapKey, configMap)
+				if apierrors.IsNotFound(err) {
+					configMap.Name = pauseConfigMapKey.Name
+					configMap.Namespace = pauseConfigMapKey.Namespace
+					configMap.Data = map[string]string{PauseKey: value}
+					return k8sClient.Create(ctx, configMap)
+				}
+				if err != nil {
+					return err
+				}
+				configMap.Data = map[string]string{PauseKey: value}
+				return k8sClient.Update(ctx, configMap)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			setPaused("true")
+			DeferCleanup(func() { setPaused("false") })
+		})
+
+		It("reports Paused and writes nothing until resumed", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionPaused))).To(BeTrue())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name: secretKey.Name, Namespace: secretKey.Namespace,
+			}})).To(Succeed())
+			Consistently(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, secretKey, &corev1.Secret{}))
+			}, 2*time.Second, 100*time.Millisecond).Should(BeTrue())
+
+			setPaused("false")
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("clears Paused once resumed without drift", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionPaused))).To(BeTrue())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			setPaused("false")
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionPaused))).To(BeFalse())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller dryRun", func() {
+	Context("with a dry-run policy", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			testData.dryRun = true
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+		})
+
+		It("reports planned changes without writing", func() {
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionDryRun))).To(BeTrue())
+				g.Expect(identity.Status.DryRun).NotTo(BeNil())
+				g.Expect(identity.Status.DryRun.Namespaces).To(ContainElement(SatisfyAll(
+					HaveField("Namespace", testData.targetNamespaces[0]),
+					HaveField("WouldCreate", ConsistOf(
+						"Secret/"+testData.secretName,
+						"ServiceAccount/"+testData.serviceAccountName,
+					)),
+				)))
+				g.Expect(identity.Status.ObservedSourceSecretHash).To(BeEmpty())
+				g.Expect(meta.FindStatusCondition(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeNil())
+				g.Expect(meta.FindStatusCondition(identity.Status.Conditions, string(v1alpha1.ConditionDegraded))).To(BeNil())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			for _, namespace := range testData.targetNamespaces {
+				secretKey := types.NamespacedName{Name: testData.secretName, Namespace: namespace}
+				Expect(apierrors.IsNotFound(k8sClient.Get(ctx, secretKey, &corev1.Secret{}))).To(BeTrue())
+			}
+		})
+
+		It("writes the planned changes once dryRun is unset", func() {
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				if err := k8sClient.Get(ctx, identityKey, identity); err != nil {
+					return err
+				}
+				identity.Spec.DryRun = false
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: testData.targetNamespaces[0]}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			Eventually(func(g Gomega) {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				g.Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+				g.Expect(identity.Status.DryRun).To(BeNil())
+				g.Expect(meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady))).To(BeTrue())
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller createNamespaces", func() {
+	Context("with namespace creation enabled", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+		createdNs := ""
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+
+			createdNs = uniqueStr("tenant")
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				if err := k8sClient.Get(ctx, identityKey, identity); err != nil {
+					return err
+				}
+				identity.Spec.TargetNamespaces = append(identity.Spec.TargetNamespaces, createdNs)
+				identity.Spec.CreateNamespaces = v1alpha1.CreateNamespaces{
+					Enabled: true,
+					Labels:  map[string]string{"team": "payments"},
+				}
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+
+		It("creates the missing namespace and syncs into it", func() {
+			Eventually(func(g Gomega) {
+				namespace := &corev1.Namespace{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdNs}, namespace)).To(Succeed())
+				g.Expect(namespace.Labels).To(HaveKeyWithValue("team", "payments"))
+				g.Expect(namespace.Labels).To(HaveKey(LabelPolicyUID))
+				g.Expect(namespace.Annotations).To(HaveKeyWithValue(AnnotationCreatedBy, namespace.Labels[LabelPolicyUID]))
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			secretKey := types.NamespacedName{Name: testData.secretName, Namespace: createdNs}
+			Eventually(func() string {
+				s := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, secretKey, s)
+				return string(s.Data[testData.tokenName])
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(testData.tokenValue))
+
+			existing := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testData.targetNamespaces[0]}, existing)).To(Succeed())
+			Expect(existing.Labels).NotTo(HaveKey(LabelPolicyUID))
+		})
+
+		It("deletes only the namespaces it created with the policy", func() {
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: createdNs}, &corev1.Namespace{})
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+			identity := &v1alpha1.IdentitySyncPolicy{}
+			Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, identity)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, identityKey, &v1alpha1.IdentitySyncPolicy{}))
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+
+			created := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdNs}, created)).To(Succeed())
+			Expect(created.DeletionTimestamp.IsZero()).To(BeFalse())
+
+			existing := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testData.targetNamespaces[0]}, existing)).To(Succeed())
+			Expect(existing.DeletionTimestamp.IsZero()).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("IdentitySyncPolicy Controller deletionPolicy", func() {
+	Context("when the policy is deleted", func() {
+
+		ctx := context.Background()
+		testData := &identityFixture{}
+		identityKey := types.NamespacedName{}
+
+		BeforeEach(func() {
+			testData = newIdentityFixture()
+			seedIdentityFixture(testData)
+			identityKey = types.NamespacedName{Name: testData.identityName, Namespace: testData.namespaceName}
+
+			for _, targetNamespace := range testData.targetNamespaces {
+				secretKey := types.NamespacedName{Name: testData.secretName, Namespace: targetNamespace}
+				Eventually(func() error {
+					return k8sClient.Get(ctx, secretKey, &corev1.Secret{})
+				}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+			}
+		})
+
+		setDeletionPolicy := func(policy v1alpha1.DeletionPolicy) {
+			Eventually(func() error {
+				identity := &v1alpha1.IdentitySyncPolicy{}
+				if err := k8sClient.Get(ctx, identityKey, identity); err != nil {
+					return err
+				}
+				identity.Spec.DeletionPolicy = policy
+				return k8sClient.Update(ctx, identity)
+			}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+		}
+
+		deleteIdentity := func() {
+			identity := &v1alpha1.IdentitySyncPolicy{}
+			Expect(k8sClient.Get(ctx, identityKey, identity)).To(Succeed())
+			Expect(identity.Finalizers).To(ContainElement(Finalizer))
+			Expect(k8sClient.Delete(ctx, identity)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, identityKey, &v1alpha1.IdentitySyncPolicy{}))
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+		}
+
+		It("deletes targets with the default Delete policy", func() {
+			deleteIdentity()
+
+			for _, targetNamespace := range testData.targetNamespaces {
+				secretKey := types.NamespacedName{Name: testData.secretName, Namespace: targetNamespace}
+				Expect(apierrors.IsNotFound(k8sClient.Get(ctx, secretKey, &corev1.Secret{}))).To(BeTrue())
+				saKey := types.NamespacedName{Name: testData.serviceAccountName, Namespace: targetNamespace}
+				Expect(apierrors.IsNotFound(k8sClient.Get(ctx, saKey, &corev1.ServiceAccount{}))).To(BeTrue())
+			}
+		})
+
+		It("leaves unmanaged targets behind with the Orphan policy", func() {
+			setDeletionPolicy(v1alpha1.DeletionPolicyOrphan)
+			deleteIdentity()
+
+			for _, targetNamespace := range testData.targetNamespaces {
+				secret := &corev1.Secret{}
+				secretKey := types.NamespacedName{Name: testData.secretName, Namespace: targetNamespace}
+				Expect(k8sClient.Get(ctx, secretKey, secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(BeEmpty())
+				Expect(secret.Labels).NotTo(HaveKey(LabelPolicyUID))
+				Expect(string(secret.Data[testData.tokenName])).To(Equal(testData.tokenValue))
+
+				sa := &corev1.ServiceAccount{}
+				saKey := types.NamespacedName{Name: testData.serviceAccountName, Namespace: targetNamespace}
+				Expect(k8sClient.Get(ctx, saKey, sa)).To(Succeed())
+				Expect(sa.OwnerReferences).To(BeEmpty())
+				Expect(sa.Labels).NotTo(HaveKey(LabelManagedBy))
+			}
 		})
 	})
 })
@@ -180,6 +943,8 @@ func createIdentity(ctx context.Context, testData identityFixture, c client.Clie
 		},
 		Spec: v1alpha1.IdentitySyncPolicySpec{
 			TargetNamespaces: testData.targetNamespaces,
+			ConflictPolicy:   testData.conflictPolicy,
+			DryRun:           testData.dryRun,
 			ServiceAccount: v1alpha1.ServiceAccount{
 				Name: testData.serviceAccountName,
 			},
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+// changeAction is the write needed to bring one object to its desired state.
+type changeAction int
+
+const (
+	changeNone changeAction = iota
+	changeCreate
+	changeUpdate
+	changeDelete
+)
+
+// plannedChange is a write a dry run skipped.
+type plannedChange struct {
+	namespace string
+	action    changeAction
+	// object is a Kind/name reference.
+	object string
+}
+
+func appendChange(changes []plannedChange, namespace string, action changeAction, kind, name string) []plannedChange {
+	if action == changeNone {
+		return changes
+	}
+	return append(changes, plannedChange{namespace: namespace, action: action, object: kind + "/" + name})
+}
+
+// hasManagedMetadata reports whether obj is controlled by the policy and
+// carries every management label, so applying them would not change it.
+func hasManagedMetadata(obj metav1.Object, identity *v1alpha1.IdentitySyncPolicy) bool {
+	if !metav1.IsControlledBy(obj, identity) {
+		return false
+	}
+	labels := obj.GetLabels()
+	for key, value := range managedMetadataLabels(identity) {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// buildDryRunStatus groups the planned changes by namespace, sorted so
+// repeated dry runs with the same plan produce an identical status.
+func buildDryRunStatus(changes []plannedChange) *v1alpha1.DryRunStatus {
+	byNamespace := make(map[string]*v1alpha1.NamespaceChanges)
+	for _, change := range changes {
+		entry, ok := byNamespace[change.namespace]
+		if !ok {
+			entry = &v1alpha1.NamespaceChanges{Namespace: change.namespace}
+			byNamespace[change.namespace] = entry
+		}
+		switch change.action {
+		case changeCreate:
+			entry.WouldCreate = append(entry.WouldCreate, change.object)
+		case changeUpdate:
+			entry.WouldUpdate = append(entry.WouldUpdate, change.object)
+		case changeDelete:
+			entry.WouldDelete = append(entry.WouldDelete, change.object)
+		}
+	}
+
+	st := &v1alpha1.DryRunStatus{}
+	for _, entry := range byNamespace {
+		sort.Strings(entry.WouldCreate)
+		sort.Strings(entry.WouldUpdate)
+		sort.Strings(entry.WouldDelete)
+		st.Namespaces = append(st.Namespaces, *entry)
+	}
+	sort.Slice(st.Namespaces, func(i, j int) bool {
+		return st.Namespaces[i].Namespace < st.Namespaces[j].Namespace
+	})
+	return st
+}
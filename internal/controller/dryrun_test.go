@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestBuildDryRunStatus(t *testing.T) {
+	changes := []plannedChange{
+		{namespace: "app-2", action: changeUpdate, object: "Secret/token"},
+		{namespace: "app-1", action: changeCreate, object: "Secret/token"},
+		{namespace: "app-1", action: changeCreate, object: "ServiceAccount/app"},
+		{namespace: "old", action: changeDelete, object: "Secret/token"},
+		{namespace: "app-1", action: changeCreate, object: "Namespace/app-1"},
+	}
+
+	got := buildDryRunStatus(changes)
+	want := &v1alpha1.DryRunStatus{Namespaces: []v1alpha1.NamespaceChanges{
+		{Namespace: "app-1", WouldCreate: []string{"Namespace/app-1", "Secret/token", "ServiceAccount/app"}},
+		{Namespace: "app-2", WouldUpdate: []string{"Secret/token"}},
+		{Namespace: "old", WouldDelete: []string{"Secret/token"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("buildDryRunStatus() = %+v, want %+v", got, want)
+	}
+
+	if got := buildDryRunStatus(nil); len(got.Namespaces) != 0 {
+		t.Fatalf("buildDryRunStatus(nil) = %+v, want no namespaces", got)
+	}
+}
+
+func TestAppendChangeSkipsNone(t *testing.T) {
+	changes := appendChange(nil, "app-1", changeNone, "Secret", "token")
+	changes = appendChange(changes, "app-1", changeUpdate, "Secret", "token")
+	want := []plannedChange{{namespace: "app-1", action: changeUpdate, object: "Secret/token"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("appendChange() = %+v, want %+v", changes, want)
+	}
+}
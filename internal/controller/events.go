@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+)
+
+// Event reasons emitted on policies and target Secrets.
+const (
+	EventSourceMissing   = "SourceMissing"
+	EventFanoutPartial   = "FanoutPartial"
+	EventFanoutRecovered = "FanoutRecovered"
+	EventTargetPruned    = "TargetPruned"
+)
+
+// recordEvents emits Events for the transitions of one reconcile. The policy
+// gets an Event per transition; failing and recovered namespaces also get one
+// on their target Secrets, so tenants see them without reading the policy.
+//
+// --- Throttling ---
+// Events are fingerprinted like failure logs: the same reason and message for
+// the same object is emitted at most once per interval, so a flapping target
+// does not flood the namespace.
+func (c *Controller) recordEvents(f reconcileContext, prevTargets []v1alpha1.TargetStatus) {
+	if c.events == nil || f.conditions == nil {
+		return
+	}
+	identity := f.identity
+	intervals := c.tuning().Logging
+
+	degradedFrom, degradedTo := f.conditions.Transition(string(v1alpha1.ConditionDegraded))
+	sourceFrom, sourceTo := f.conditions.Transition(string(v1alpha1.ConditionReferenceSecretReady))
+
+	if sourceTo == metav1.ConditionFalse && sourceFrom != metav1.ConditionFalse && f.decision.Reason == result.ReasonNotFound {
+		c.emit(identity, corev1.EventTypeWarning, EventSourceMissing, intervals.ReminderInterval(result.ReasonNotFound),
+			missingSourcesMessage(f.missingSources))
+	}
+	if f.phase == observability.PhaseFanout && degradedTo == metav1.ConditionTrue && degradedFrom != metav1.ConditionTrue {
+		message := f.decision.Msg
+		if namespaces := failedNamespaces(f.observation); len(namespaces) > 0 {
+			message += ": " + strings.Join(namespaces, ", ")
+		}
+		c.emit(identity, corev1.EventTypeWarning, EventFanoutPartial, intervals.ReminderInterval(f.decision.Reason), message)
+	}
+	if degradedTo == metav1.ConditionFalse && degradedFrom == metav1.ConditionTrue {
+		c.emit(identity, corev1.EventTypeNormal, EventFanoutRecovered, intervals.ChangeInterval.Duration,
+			"All target namespaces are in sync")
+	}
+	if f.observation == nil || f.phase != observability.PhaseFanout || f.dryRun {
+		return
+	}
+	if f.observation.Pruned > 0 {
+		c.emit(identity, corev1.EventTypeNormal, EventTargetPruned, intervals.ChangeInterval.Duration,
+			fmt.Sprintf("Deleted %d stale target objects", f.observation.Pruned))
+	}
+
+	prevStates := make(map[string]v1alpha1.TargetState, len(prevTargets))
+	for _, target := range prevTargets {
+		prevStates[target.Namespace] = target.State
+	}
+	for _, res := range f.observation.Results {
+		prev := prevStates[res.Namespace]
+		switch {
+		case res.Failed && prev != v1alpha1.TargetStateFailed:
+			c.emitOnTargets(f, intervals, res.Namespace, corev1.EventTypeWarning, EventFanoutPartial,
+				fmt.Sprintf("Policy %s failed to sync this namespace (%s): %s", identity.Name, res.Reason, res.Message))
+		case !res.Failed && res.Skipped == "" && prev == v1alpha1.TargetStateFailed:
+			c.emitOnTargets(f, intervals, res.Namespace, corev1.EventTypeNormal, EventFanoutRecovered,
+				fmt.Sprintf("Policy %s synced this namespace again", identity.Name))
+		}
+	}
+}
+
+func (c *Controller) emitOnTargets(
+	f reconcileContext,
+	intervals config.Logging,
+	namespace, eventType, reason, message string,
+) {
+	for _, src := range f.sources {
+		target := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: src.spec.Name}}
+		c.emit(target, eventType, reason, intervals.ReminderInterval(f.decision.Reason), message)
+	}
+}
+
+func (c *Controller) emit(obj runtime.Object, eventType, reason string, interval time.Duration, message string) {
+	if c.limiter != nil {
+		key := eventKey(obj)
+		fp := fmt.Sprintf("evt|%s|%s|%s", key, reason, hashStrings([]string{message}))
+		if !c.limiter.Allow(fp, time.Now(), interval) {
+			return
+		}
+	}
+	c.events.Event(obj, eventType, reason, truncate(message, maxTargetMessageLen))
+}
+
+func eventKey(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	if uid := accessor.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return accessor.GetNamespace() + "/" + accessor.GetName()
+}
+
+// failedNamespaces lists the namespaces that failed in the observation.
+func failedNamespaces(obs *Observation) []string {
+	if obs == nil {
+		return nil
+	}
+	var namespaces []string
+	for _, res := range obs.Results {
+		if res.Failed {
+			namespaces = append(namespaces, res.Namespace)
+		}
+	}
+	return namespaces
+}
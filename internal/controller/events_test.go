@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/logging"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+	"github.com/lapacek-labs/identity-operator/pkg/status"
+)
+
+func TestRecordEvents(t *testing.T) {
+	identity := &v1alpha1.IdentitySyncPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: "uid-1"}}
+	sources := []source{{spec: v1alpha1.Secret{Name: "token"}}}
+
+	degraded := func(from, to metav1.ConditionStatus) *status.ConditionSet {
+		conditions := status.NewConditionSet([]metav1.Condition{
+			{Type: string(v1alpha1.ConditionDegraded), Status: from, Reason: "Previous"},
+		}, 1, time.Now())
+		conditions.Set(string(v1alpha1.ConditionDegraded), to, "Current", "")
+		return conditions
+	}
+	partial := func() *Observation {
+		obs := NewObservation(2, 8)
+		obs.ObserveSuccess("app-1")
+		obs.ObserveFailure("app-2", errclass.KindTransient, errclass.ReasonTimeout, nil)
+		return obs
+	}
+	recovered := func() *Observation {
+		obs := NewObservation(2, 8)
+		obs.ObserveSuccess("app-1")
+		obs.ObserveSuccess("app-2")
+		return obs
+	}
+	failedApp2 := []v1alpha1.TargetStatus{{Namespace: "app-2", State: v1alpha1.TargetStateFailed}}
+
+	tests := []struct {
+		name        string
+		conditions  *status.ConditionSet
+		observation *Observation
+		prevTargets []v1alpha1.TargetStatus
+		want        []string
+	}{
+		{
+			name:        "degraded",
+			conditions:  degraded(metav1.ConditionFalse, metav1.ConditionTrue),
+			observation: partial(),
+			want:        []string{"Warning FanoutPartial", "Warning FanoutPartial"},
+		},
+		{
+			name:        "still_degraded",
+			conditions:  degraded(metav1.ConditionTrue, metav1.ConditionTrue),
+			observation: partial(),
+			prevTargets: failedApp2,
+		},
+		{
+			name:        "recovered",
+			conditions:  degraded(metav1.ConditionTrue, metav1.ConditionFalse),
+			observation: recovered(),
+			prevTargets: failedApp2,
+			want:        []string{"Normal FanoutRecovered", "Normal FanoutRecovered"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			c := &Controller{events: recorder, limiter: logging.NewLimiter(16)}
+			c.recordEvents(reconcileContext{
+				phase:       observability.PhaseFanout,
+				decision:    result.Decision{Reason: result.ReasonTimeout},
+				identity:    identity,
+				conditions:  tt.conditions,
+				observation: tt.observation,
+				sources:     sources,
+			}, tt.prevTargets)
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				fields := strings.Fields(event)
+				got = append(got, fields[0]+" "+fields[1])
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordEventsThrottlesRepeats(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{events: recorder, limiter: logging.NewLimiter(16)}
+	identity := &v1alpha1.IdentitySyncPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: "uid-1"}}
+
+	for range 3 {
+		c.emit(identity, "Normal", EventTargetPruned, time.Minute, "Deleted 1 stale target objects")
+	}
+	close(recorder.Events)
+
+	if got := len(recorder.Events); got != 1 {
+		t.Fatalf("emitted %d events, want 1", got)
+	}
+}
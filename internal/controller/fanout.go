@@ -5,15 +5,22 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/result"
 )
 
 func reconcileIdentity(
@@ -21,85 +28,390 @@ func reconcileIdentity(
 	k8sScheme *runtime.Scheme,
 	k8sClient client.Client,
 	identity *v1alpha1.IdentitySyncPolicy,
-	secret *corev1.Secret,
+	targetNamespaces []string,
+	sources []source,
+	opts fanoutOptions,
 ) *Observation {
-	const maxSample = 50
-	observation := NewObservation(len(identity.Spec.TargetNamespaces), maxSample)
-	targetNamespaces := identity.Spec.TargetNamespaces
-	for _, namespace := range targetNamespaces {
-		if fanoutErr := reconcileNamespace(ctx, k8sScheme, k8sClient, identity, namespace, secret); fanoutErr != nil {
+	observation := NewObservation(len(targetNamespaces)+len(opts.sourceNamespaces), opts.maxSamples)
+	for _, namespace := range opts.sourceNamespaces {
+		observation.ObserveSkipped(namespace, v1alpha1.TargetStateSkipped, errclass.ReasonSourceNamespace,
+			fmt.Errorf("namespace %s holds a source Secret", namespace))
+	}
+	forEachNamespace(targetNamespaces, opts.parallelism, func(namespace string) {
+		if prev, ok := opts.backoff[namespace]; ok {
+			observation.ObserveBackoff(prev)
+			return
+		}
+		if opts.protected.Protected(namespace) {
+			observation.ObserveSkipped(namespace, v1alpha1.TargetStateSkipped, errclass.ReasonForbidden,
+				fmt.Errorf("namespace %s is protected", namespace))
+			return
+		}
+		state, reason, err := unwritableNamespace(ctx, k8sClient, namespace)
+		if state == v1alpha1.TargetStateNamespaceMissing && shouldCreateNamespace(identity, namespace, opts.protected) {
+			if err := createTargetNamespace(ctx, k8sClient, identity, namespace, opts.dryRun); err != nil {
+				kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+				observation.ObserveFailure(namespace, kind, reason, err)
+				return
+			}
+			if opts.dryRun {
+				// Nothing can be dry-run inside a namespace that does not exist.
+				changes, err := planNewNamespace(identity, namespace, sources)
+				observation.ObservePlanned(changes...)
+				if err != nil {
+					kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+					observation.ObserveFailure(namespace, kind, reason, err)
+					return
+				}
+				observation.ObserveSuccess(namespace)
+				return
+			}
+			state = ""
+		}
+		if state != "" {
+			observation.ObserveSkipped(namespace, state, reason, err)
+			return
+		}
+		nsCtx, span := opts.tracer.Start(ctx, observability.SpanReconcileNamespace,
+			observability.String(observability.AttrPolicy, client.ObjectKeyFromObject(identity).String()),
+			observability.String(observability.AttrNamespace, namespace),
+		)
+		defer span.End()
+		changes, fanoutErr := reconcileNamespace(nsCtx, k8sScheme, k8sClient, identity, namespace, sources, opts.dryRun)
+		if opts.dryRun {
+			observation.ObservePlanned(changes...)
+		}
+		if fanoutErr != nil {
 			kind, reason := errclass.ClassifyError(fanoutErr, errclass.NotFoundAsTransient)
-			observation.ObserveFailure(namespace, kind, reason, fanoutErr)
-			continue
+			outcome := result.OutcomeFailed
+			switch {
+			case reason == errclass.ReasonNamespaceTerminating:
+				// Deletion started after the namespace was checked.
+				outcome = result.OutcomeSkipped
+				observation.ObserveSkipped(namespace, v1alpha1.TargetStateTerminating, reason, fanoutErr)
+			case reason == errclass.ReasonTargetConflict && identity.Spec.ConflictPolicy == v1alpha1.ConflictPolicySkip:
+				outcome = result.OutcomeSkipped
+				observation.ObserveSkipped(namespace, v1alpha1.TargetStateSkipped, reason, fanoutErr)
+			default:
+				observation.ObserveFailure(namespace, kind, reason, fanoutErr)
+			}
+			attrs := []observability.Attribute{
+				observability.String(observability.AttrOutcome, string(outcome)),
+				observability.String(observability.AttrErrorKind, string(kind)),
+				observability.String(observability.AttrErrorReason, string(reason)),
+			}
+			if outcome == result.OutcomeSkipped {
+				span.SetAttributes(attrs...)
+				return
+			}
+			span.Fail(fanoutErr, attrs...)
+			return
 		}
-		observation.ObserveSuccess()
-	}
+		span.SetAttributes(observability.String(observability.AttrOutcome, string(result.OutcomeSuccess)))
+		observation.ObserveSuccess(namespace)
+	})
+	pruneStaleTargets(ctx, k8sScheme, k8sClient, identity, targetNamespaces, opts.dryRun, observation)
+	observation.Sort()
 	return observation
 }
 
+// fanoutOptions are the settings of a single fan-out.
+type fanoutOptions struct {
+	parallelism int
+	// protected namespaces are never created nor written into.
+	protected guard.Namespaces
+	// dryRun sends every write as a server-side dry run and records it in the
+	// observation instead.
+	dryRun bool
+	// tracer traces every namespace that is written into.
+	tracer observability.Tracer
+	// maxSamples bounds the failures the observation keeps.
+	maxSamples int
+	// backoff are the failed namespaces not due for a retry yet, by namespace.
+	// They are reported with their last failure and not written into.
+	backoff map[string]v1alpha1.TargetStatus
+	// sourceNamespaces were left out of the targets and are reported as skipped.
+	sourceNamespaces []string
+}
+
+// forEachNamespace calls fn for every namespace with at most parallelism calls
+// in flight, and returns once all of them completed.
+func forEachNamespace(namespaces []string, parallelism int, fn func(namespace string)) {
+	if parallelism <= 1 {
+		for _, namespace := range namespaces {
+			fn(namespace)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, parallelism)
+	for _, namespace := range namespaces {
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			fn(namespace)
+		})
+	}
+	wg.Wait()
+}
+
 func reconcileNamespace(
 	ctx context.Context,
 	k8sScheme *runtime.Scheme,
 	k8sClient client.Client,
 	identity *v1alpha1.IdentitySyncPolicy,
 	namespace string,
-	sourceSecret *corev1.Secret,
-) error {
-	if err := ensureServiceAccount(ctx, k8sScheme, k8sClient, identity, namespace); err != nil {
-		return err
+	sources []source,
+	dryRun bool,
+) ([]plannedChange, error) {
+	// A namespace reported as skipped or failed due to a conflict must be left
+	// exactly as it was, so every target is checked before the first write.
+	if err := checkNamespaceConflicts(ctx, k8sClient, identity, namespace, sources); err != nil {
+		return nil, err
 	}
-	if err := ensureSecret(ctx, k8sScheme, k8sClient, identity, namespace, sourceSecret); err != nil {
-		return err
+	action, err := ensureServiceAccount(ctx, k8sScheme, k8sClient, identity, namespace, dryRun)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	changes := appendChange(nil, namespace, action, "ServiceAccount", identity.Spec.ServiceAccount.Name)
+
+	// One failing Secret must not keep the others from syncing; the namespace
+	// reports the first failure.
+	var firstErr error
+	for _, src := range sources {
+		action, err := ensureSecret(ctx, k8sScheme, k8sClient, identity, namespace, src, dryRun)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		changes = appendChange(changes, namespace, action, "Secret", src.spec.Name)
+	}
+	return changes, firstErr
+}
+
+// planNewNamespace lists the objects a real run would create in a namespace it
+// creates, after checking that the Secret data can be rendered.
+func planNewNamespace(identity *v1alpha1.IdentitySyncPolicy, namespace string, sources []source) ([]plannedChange, error) {
+	changes := appendChange(nil, namespace, changeCreate, "Namespace", namespace)
+	changes = appendChange(changes, namespace, changeCreate, "ServiceAccount", identity.Spec.ServiceAccount.Name)
+	for _, src := range sources {
+		if _, err := desiredSecretData(src.spec, src.secret, namespace); err != nil {
+			return changes, err
+		}
+		changes = appendChange(changes, namespace, changeCreate, "Secret", src.spec.Name)
+	}
+	return changes, nil
 }
 
+// ensureServiceAccount applies the operator's labels and controller reference.
+// Other fields, such as imagePullSecrets added by other controllers, are left
+// to their owners. Ownership is only forced on ServiceAccounts the policy
+// already controls; on anything else a field ownership conflict is reported.
 func ensureServiceAccount(
 	ctx context.Context,
 	k8sScheme *runtime.Scheme,
 	k8sClient client.Client,
 	identity *v1alpha1.IdentitySyncPolicy,
 	namespace string,
+	dryRun bool,
+) (changeAction, error) {
+	name := identity.Spec.ServiceAccount.Name
+	existing := &corev1.ServiceAccount{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return changeNone, err
+	}
+	action := changeNone
+	switch {
+	case apierrors.IsNotFound(err):
+		action = changeCreate
+	case !hasManagedMetadata(existing, identity):
+		action = changeUpdate
+	}
+	if action == changeNone {
+		// Retries of a failing namespace re-run the whole fan-out; in-sync
+		// namespaces must not be written again.
+		return changeNone, nil
+	}
+	force := apierrors.IsNotFound(err) || metav1.IsControlledBy(existing, identity)
+
+	owner, err := controllerReference(identity, k8sScheme)
+	if err != nil {
+		return changeNone, err
+	}
+	serviceAccount := corev1ac.ServiceAccount(name, namespace).
+		WithLabels(managedMetadataLabels(identity)).
+		WithOwnerReferences(owner)
+	return action, k8sClient.Apply(ctx, serviceAccount, applyOptions(force, dryRun)...)
+}
+
+// ensureSecret applies the desired data, type, labels and controller reference.
+// Once checkNamespaceConflicts allowed the write, the Secret content belongs to the
+// policy and ownership of those fields is forced.
+func ensureSecret(
+	ctx context.Context,
+	k8sScheme *runtime.Scheme,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	namespace string,
+	src source,
+	dryRun bool,
+) (changeAction, error) {
+	data, err := desiredSecretData(src.spec, src.secret, namespace)
+	if err != nil {
+		return changeNone, err
+	}
+	secretType := projectSecretType(src.secret.Type, data)
+
+	existing := &corev1.Secret{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: src.spec.Name}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return changeNone, err
+	}
+	action := changeNone
+	switch {
+	case apierrors.IsNotFound(err):
+		action = changeCreate
+	case !hasManagedMetadata(existing, identity) || !dataEqual(existing.Data, data) || existing.Type != secretType:
+		action = changeUpdate
+	}
+	if action == changeNone {
+		return changeNone, nil
+	}
+
+	owner, err := controllerReference(identity, k8sScheme)
+	if err != nil {
+		return changeNone, err
+	}
+	secret := corev1ac.Secret(src.spec.Name, namespace).
+		WithLabels(managedMetadataLabels(identity)).
+		WithOwnerReferences(owner).
+		WithType(secretType).
+		WithData(data)
+	if err := k8sClient.Apply(ctx, secret, applyOptions(true, dryRun)...); err != nil {
+		return action, err
+	}
+	return action, removeExtraKeys(ctx, k8sClient, existing, data, dryRun)
+}
+
+// removeExtraKeys deletes the data keys of target that are not desired. The
+// apply only drops keys this field manager wrote before; keys another manager
+// added have to be removed explicitly, or the target would never match.
+func removeExtraKeys(
+	ctx context.Context,
+	k8sClient client.Client,
+	target *corev1.Secret,
+	desired map[string][]byte,
+	dryRun bool,
 ) error {
-	targetServiceAccount := &corev1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      identity.Spec.ServiceAccount.Name,
-		},
-	}
-	_, err := controllerutil.CreateOrPatch(ctx, k8sClient, targetServiceAccount, func() error {
-		ensureManagedMetadata(&targetServiceAccount.ObjectMeta, identity)
-		if err := controllerutil.SetControllerReference(identity, targetServiceAccount, k8sScheme); err != nil {
-			return err
+	if target.ResourceVersion == "" {
+		return nil
+	}
+	base := target.DeepCopy()
+	for key := range target.Data {
+		if _, ok := desired[key]; !ok {
+			delete(target.Data, key)
 		}
+	}
+	if len(target.Data) == len(base.Data) {
 		return nil
-	})
-	return err
+	}
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	return client.IgnoreNotFound(k8sClient.Patch(ctx, target, client.MergeFrom(base), opts...))
 }
 
-func ensureSecret(
+func applyOptions(force, dryRun bool) []client.ApplyOption {
+	opts := []client.ApplyOption{client.FieldOwner(FieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	return opts
+}
+
+// checkNamespaceConflicts runs checkTargetConflict on the ServiceAccount and
+// every target Secret in namespace, and returns the first conflict.
+func checkNamespaceConflicts(
 	ctx context.Context,
-	k8sScheme *runtime.Scheme,
 	k8sClient client.Client,
 	identity *v1alpha1.IdentitySyncPolicy,
 	namespace string,
-	sourceSecret *corev1.Secret,
+	sources []source,
 ) error {
-	targetSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      identity.Spec.Secret.Name,
-			Namespace: namespace,
-		},
-		Type: corev1.SecretTypeOpaque,
-	}
-	_, err := controllerutil.CreateOrPatch(ctx, k8sClient, targetSecret, func() error {
-		ensureManagedMetadata(&targetSecret.ObjectMeta, identity)
-		if err := controllerutil.SetControllerReference(identity, targetSecret, k8sScheme); err != nil {
+	if err := checkExistingTarget(ctx, k8sClient, identity, namespace,
+		identity.Spec.ServiceAccount.Name, "serviceaccount", &corev1.ServiceAccount{}); err != nil {
+		return err
+	}
+	for _, src := range sources {
+		if err := checkExistingTarget(ctx, k8sClient, identity, namespace,
+			src.spec.Name, "secret", &corev1.Secret{}); err != nil {
 			return err
 		}
-		targetSecret.Data = sourceSecret.Data
-		targetSecret.Type = sourceSecret.Type
+	}
+	return nil
+}
+
+// checkExistingTarget reads the named target into obj and checks it for a
+// conflict. A target that does not exist yet never conflicts.
+func checkExistingTarget(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	namespace, name, kind string,
+	obj client.Object,
+) error {
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	if apierrors.IsNotFound(err) {
 		return nil
-	})
-	return err
+	}
+	if err != nil {
+		return err
+	}
+	return checkTargetConflict(obj, kind, identity)
+}
+
+// checkTargetConflict refuses to take over an existing target the policy does
+// not manage, unless spec.conflictPolicy is Adopt. A target controlled by
+// another owner is never taken over.
+func checkTargetConflict(target metav1.Object, kind string, identity *v1alpha1.IdentitySyncPolicy) error {
+	if target.GetResourceVersion() == "" || isOwnTarget(target, identity) {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(target); owner != nil {
+		return errclass.NewError(errclass.KindConfig, errclass.ReasonTargetConflict,
+			fmt.Errorf("%s %s/%s is controlled by %s %s",
+				kind, target.GetNamespace(), target.GetName(), owner.Kind, owner.Name))
+	}
+	if identity.Spec.ConflictPolicy == v1alpha1.ConflictPolicySkip ||
+		identity.Spec.ConflictPolicy == v1alpha1.ConflictPolicyFail {
+		return errclass.NewError(errclass.KindConfig, errclass.ReasonTargetConflict,
+			fmt.Errorf("%s %s/%s exists and is not managed by policy %s",
+				kind, target.GetNamespace(), target.GetName(), identity.Name))
+	}
+	return nil
+}
+
+// isOwnTarget reports whether obj is controlled by the policy, or carries the
+// policy's management labels including its UID and lost its owner reference.
+// The name labels alone can be copied onto any Secret, so they never make an
+// object the policy's own.
+func isOwnTarget(obj metav1.Object, identity *v1alpha1.IdentitySyncPolicy) bool {
+	if metav1.IsControlledBy(obj, identity) {
+		return true
+	}
+	if metav1.GetControllerOf(obj) != nil {
+		return false
+	}
+	labels := obj.GetLabels()
+	return labels[LabelManagedBy] == ID+"-operator" &&
+		labels[LabelPolicyName] == identity.Name &&
+		labels[LabelPolicyUID] == string(identity.UID)
 }
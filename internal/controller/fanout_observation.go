@@ -4,9 +4,13 @@
 package controller
 
 import (
+	"math/rand/v2"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
 	"github.com/lapacek-labs/identity-operator/pkg/errclass"
 	"github.com/lapacek-labs/identity-operator/pkg/result"
 )
@@ -14,13 +18,78 @@ import (
 type Policy struct {
 	TransientDelay time.Duration
 	PermanentDelay time.Duration
+	// MaxBackoff caps every delay, jitter included. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is the largest random part added to a delay, as a fraction of it.
+	Jitter float64
+
+	// random returns a number in [0, 1); nil uses math/rand.
+	random func() float64
 }
 
-func DefaultPolicy() Policy {
+// NewPolicy returns the policy with the configured delays.
+func NewPolicy(retry config.Retry) Policy {
 	return Policy{
-		TransientDelay: 2 * time.Minute,
-		PermanentDelay: 10 * time.Minute,
+		TransientDelay: retry.TransientDelay.Duration,
+		PermanentDelay: retry.PermanentDelay.Duration,
+		MaxBackoff:     retry.MaxBackoff.Duration,
+		Jitter:         float64(retry.Jitter) / 100,
+	}
+}
+
+// WithRetryPolicy returns p with the fields spec.retryPolicy sets.
+func (p Policy) WithRetryPolicy(rp v1alpha1.RetryPolicy) Policy {
+	if rp.TransientDelay != nil {
+		p.TransientDelay = rp.TransientDelay.Duration
+	}
+	if rp.PermanentDelay != nil {
+		p.PermanentDelay = rp.PermanentDelay.Duration
+	}
+	if rp.MaxBackoff != nil {
+		p.MaxBackoff = rp.MaxBackoff.Duration
+	} else if p.MaxBackoff > 0 {
+		// A delay set in the spec is not cut short by the operator-wide cap.
+		p.MaxBackoff = max(p.MaxBackoff, p.TransientDelay, p.PermanentDelay)
+	}
+	if rp.Jitter != nil {
+		p.Jitter = float64(*rp.Jitter) / 100
+	}
+	return p
+}
+
+// maxDoublings bounds the exponential backoff when no cap is set.
+const maxDoublings = 10
+
+// Delay returns the requeue delay after a failure, with jitter and the cap applied.
+func (p Policy) Delay(transient bool) time.Duration {
+	return p.Backoff(transient, 1)
+}
+
+// Backoff returns the delay before retrying a target namespace after its
+// failures-th failure in a row: the base delay doubled for every earlier
+// failure, with jitter and the cap applied.
+func (p Policy) Backoff(transient bool, failures int32) time.Duration {
+	delay := p.PermanentDelay
+	if transient {
+		delay = p.TransientDelay
+	}
+	for i := int32(1); i < min(failures, maxDoublings+1); i++ {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
 	}
+	if p.Jitter > 0 {
+		random := p.random
+		if random == nil {
+			random = rand.Float64
+		}
+		delay += time.Duration(random() * p.Jitter * float64(delay))
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
 }
 
 func (p Policy) Decide(obs *Observation) result.Decision {
@@ -28,13 +97,16 @@ func (p Policy) Decide(obs *Observation) result.Decision {
 	switch {
 	case obs.Total == 0:
 		outcome = result.OutcomeSuccess
-	case obs.Success == obs.Total:
+	case obs.Success+obs.Skipped == obs.Total:
 		outcome = result.OutcomeSuccess
-	case obs.Success == 0:
+	case obs.Success == 0 && obs.Skipped == 0:
 		outcome = result.OutcomeFailed
 	default:
 		outcome = result.OutcomePartial
 	}
+	if outcome == result.OutcomeSuccess && obs.PruneFailed > 0 {
+		outcome = result.OutcomePartial
+	}
 
 	dec := result.Decision{
 		Outcome: outcome,
@@ -43,25 +115,42 @@ func (p Policy) Decide(obs *Observation) result.Decision {
 	}
 
 	if outcome != result.OutcomeSuccess {
-		if obs.HasTransient {
-			dec.RequeueAfter = p.TransientDelay
-		} else {
-			dec.RequeueAfter = p.PermanentDelay
+		dec.RequeueAfter = p.Delay(obs.HasTransient)
+		if obs.RetryAfter > 0 && (obs.PruneFailed == 0 || obs.RetryAfter < dec.RequeueAfter) {
+			// Failed namespaces are due at their own times; failed prunes
+			// have none and keep the policy delay.
+			dec.RequeueAfter = obs.RetryAfter
 		}
 	}
 
 	return dec
 }
 
+// Observation collects the results of one fan-out. Observe* methods are safe
+// for concurrent use; read the fields only after the fan-out completed.
 type Observation struct {
-	Reasons      map[errclass.ErrorReason]int
-	Samples      []Sample
+	mu sync.Mutex
+
+	Reasons map[errclass.ErrorReason]int
+	Samples []Sample
+	Results []TargetResult
+	// Planned are the writes a dry run skipped.
+	Planned      []plannedChange
 	MaxSample    int
 	Success      int
 	Failed       int
+	Skipped      int
 	Total        int
+	Pruned       int
+	PruneFailed  int
 	HasTransient bool
 	HasPermanent bool
+	// BackedOff are the failed namespaces not retried because their backoff
+	// has not expired. They count against Total but not Failed or Reasons.
+	BackedOff int
+	// RetryAfter is the time until the earliest failed namespace is due, set by
+	// scheduleRetries. Zero means the namespaces were not scheduled.
+	RetryAfter time.Duration
 }
 
 const (
@@ -72,17 +161,112 @@ func NewObservation(total, maxSample int) *Observation {
 	return &Observation{
 		MaxSample: maxSample,
 		Samples:   make([]Sample, 0, min(minSample, maxSample)),
+		Results:   make([]TargetResult, 0, total),
 		Total:     total,
 	}
 }
 
-func (obs *Observation) ObserveSuccess() {
+func (obs *Observation) ObserveSuccess(namespace string) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
 	obs.Success++
+	obs.Results = append(obs.Results, TargetResult{Namespace: namespace})
 }
 
 func (obs *Observation) ObserveFailure(namespace string, kind errclass.ErrorKind, reason errclass.ErrorReason, err error) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
 	obs.Failed++
+	obs.Results = append(obs.Results, TargetResult{
+		Namespace: namespace,
+		Failed:    true,
+		Kind:      kind,
+		Reason:    reason,
+		Message:   errMessage(err),
+	})
+	obs.recordFailure(namespace, kind, reason, err)
+}
+
+// ObserveBackoff records a failed namespace that was not retried because its
+// backoff has not expired. It keeps failing with its last reason and retry
+// schedule, but as nothing was attempted it is neither counted as a failure
+// nor sampled again.
+func (obs *Observation) ObserveBackoff(prev v1alpha1.TargetStatus) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.BackedOff++
+	obs.Results = append(obs.Results, TargetResult{
+		Namespace: prev.Namespace,
+		Failed:    true,
+		BackedOff: true,
+		Reason:    errclass.ErrorReason(prev.Reason),
+		Message:   prev.Message,
+		Failures:  prev.ConsecutiveFailures,
+		RetryAt:   prev.NextRetryTime.Time,
+	})
+}
+
+// ObserveSkipped records a namespace deliberately left untouched. It counts
+// towards success but keeps its state and reason so it is still reported.
+func (obs *Observation) ObserveSkipped(
+	namespace string,
+	state v1alpha1.TargetState,
+	reason errclass.ErrorReason,
+	err error,
+) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
 
+	obs.Skipped++
+	obs.Results = append(obs.Results, TargetResult{
+		Namespace: namespace,
+		Skipped:   state,
+		Reason:    reason,
+		Message:   errMessage(err),
+	})
+}
+
+// ConflictNamespaces returns the namespaces that failed or were skipped because
+// of a target conflict.
+func (obs *Observation) ConflictNamespaces() []string {
+	var namespaces []string
+	for _, res := range obs.Results {
+		if res.Reason == errclass.ReasonTargetConflict {
+			namespaces = append(namespaces, res.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// ObservePlanned records writes a dry run skipped.
+func (obs *Observation) ObservePlanned(changes ...plannedChange) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.Planned = append(obs.Planned, changes...)
+}
+
+func (obs *Observation) ObservePruned() {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.Pruned++
+}
+
+// ObservePruneFailure records a failed deletion of a stale target. It does not
+// count against Total, which only covers the desired target namespaces.
+func (obs *Observation) ObservePruneFailure(namespace string, kind errclass.ErrorKind, reason errclass.ErrorReason, err error) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.PruneFailed++
+	obs.recordFailure(namespace, kind, reason, err)
+}
+
+func (obs *Observation) recordFailure(namespace string, kind errclass.ErrorKind, reason errclass.ErrorReason, err error) {
 	if kind == errclass.KindTransient || kind == errclass.KindConflict {
 		obs.HasTransient = true
 	}
@@ -95,25 +279,65 @@ func (obs *Observation) ObserveFailure(namespace string, kind errclass.ErrorKind
 	}
 	obs.Reasons[reason]++
 
+	sample := Sample{
+		Namespace: namespace,
+		Message:   errMessage(err),
+		Reason:    reason,
+		Kind:      kind,
+	}
 	if len(obs.Samples) < obs.MaxSample {
-		message := ""
-		if err != nil {
-			message = err.Error()
+		obs.Samples = append(obs.Samples, sample)
+		return
+	}
+	// --- Deterministic sampling ---
+	// Once full, keep the smallest samples rather than the first ones, so the
+	// retained set does not depend on the order concurrent workers finish in.
+	if obs.MaxSample <= 0 {
+		return
+	}
+	largest := 0
+	for i := range obs.Samples {
+		if sampleLess(obs.Samples[largest], obs.Samples[i]) {
+			largest = i
 		}
-		obs.Samples = append(obs.Samples, Sample{
-			Namespace: namespace,
-			Message:   message,
-			Reason:    reason,
-			Kind:      kind,
-		})
+	}
+	if sampleLess(sample, obs.Samples[largest]) {
+		obs.Samples[largest] = sample
 	}
 }
 
-func (obs *Observation) PrimaryReason() result.Reason {
-	if len(obs.Reasons) == 0 {
-		return result.ReasonUnknown
+// Sort orders Results and Samples by namespace so a concurrent fan-out
+// observes the same as a serial one.
+func (obs *Observation) Sort() {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	sort.SliceStable(obs.Results, func(i, j int) bool {
+		return obs.Results[i].Namespace < obs.Results[j].Namespace
+	})
+	sort.Slice(obs.Samples, func(i, j int) bool {
+		return sampleLess(obs.Samples[i], obs.Samples[j])
+	})
+}
+
+func sampleLess(a, b Sample) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	if a.Reason != b.Reason {
+		return a.Reason < b.Reason
 	}
+	return a.Message < b.Message
+}
+
+// PrimaryReason returns the reason that best explains the failures. When only
+// namespaces in backoff failed, it falls back to the reasons they last failed
+// with.
+func (obs *Observation) PrimaryReason() result.Reason {
 	reasons := obs.ErrorReasonCounts()
+	if len(reasons) == 0 {
+		reasons = obs.backoffReasonCounts()
+	}
 	if len(reasons) == 0 {
 		return result.ReasonUnknown
 	}
@@ -133,18 +357,40 @@ func (obs *Observation) ErrorReasonCounts() ReasonCounts {
 	return reasons
 }
 
+// backoffReasonCounts counts the last reasons of the namespaces in backoff.
+func (obs *Observation) backoffReasonCounts() ReasonCounts {
+	if obs.BackedOff == 0 {
+		return nil
+	}
+	counts := make(map[errclass.ErrorReason]int)
+	for _, res := range obs.Results {
+		if res.BackedOff {
+			counts[res.Reason]++
+		}
+	}
+	reasons := make(ReasonCounts, 0, len(counts))
+	for r, c := range counts {
+		reasons = append(reasons, ReasonCount{Reason: r, Count: c})
+	}
+	return reasons
+}
+
 func mapErrReasonToResultReason(reason errclass.ErrorReason) result.Reason {
 	switch reason {
 	case errclass.ReasonNotFound:
 		return result.ReasonNotFound
 	case errclass.ReasonForbidden:
 		return result.ReasonForbidden
-	case errclass.ReasonInvalid:
+	case errclass.ReasonInvalid, errclass.ReasonTemplate:
 		return result.ReasonInvalidSpec
 	case errclass.ReasonConflict:
 		return result.ReasonConflict
 	case errclass.ReasonTimeout:
 		return result.ReasonTimeout
+	case errclass.ReasonTargetConflict:
+		return result.ReasonTargetConflict
+	case errclass.ReasonFieldManagerConflict:
+		return result.ReasonFieldManagerConflict
 	case errclass.ReasonOther:
 		return result.ReasonAPIServerError
 	default:
@@ -152,6 +398,29 @@ func mapErrReasonToResultReason(reason errclass.ErrorReason) result.Reason {
 	}
 }
 
+func errMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// TargetResult is the outcome of reconciling a single target namespace.
+type TargetResult struct {
+	Namespace string
+	Failed    bool
+	// BackedOff marks a failed namespace that was not retried.
+	BackedOff bool
+	// Skipped is the state reported for a namespace left untouched, empty otherwise.
+	Skipped v1alpha1.TargetState
+	Kind    errclass.ErrorKind
+	Reason  errclass.ErrorReason
+	Message string
+	// Failures and RetryAt schedule the next attempt of a failed namespace.
+	Failures int32
+	RetryAt  time.Time
+}
+
 type Sample struct {
 	Namespace string
 	Message   string
@@ -188,7 +457,9 @@ func (rc ReasonCounts) SortInPlace() {
 // Higher number = higher priority in ties.
 //
 // --- Priority rationale ---
-// Invalid   -> user must fix spec/config, retries won't help.
+// TargetConflict -> an existing object must be removed or the policy changed.
+// Invalid/TemplateError -> user must fix spec/config, retries won't help.
+// FieldManagerConflict -> another controller owns a field we apply.
 // Forbidden -> RBAC/auth misconfig, also non-retriable until fixed.
 // NotFound  -> missing dependency/delete race; policy decided kind earlier.
 // Conflict  -> optimistic concurrency; retriable noise.
@@ -196,8 +467,12 @@ func (rc ReasonCounts) SortInPlace() {
 // Other     -> fallback/unknown bucket.
 func errReasonPriority(r errclass.ErrorReason) int {
 	switch r {
-	case errclass.ReasonInvalid:
+	case errclass.ReasonTargetConflict:
+		return 70 // user must resolve the conflicting object
+	case errclass.ReasonInvalid, errclass.ReasonTemplate:
 		return 60 // user must fix spec/config
+	case errclass.ReasonFieldManagerConflict:
+		return 55 // another controller owns a field we set
 	case errclass.ReasonForbidden:
 		return 50 // RBAC/auth config
 	case errclass.ReasonNotFound:
@@ -0,0 +1,398 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+func TestCheckTargetConflict(t *testing.T) {
+	identity := &v1alpha1.IdentitySyncPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "IdentitySyncPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: "uid-1"},
+	}
+	ownedBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "IdentitySyncPolicy",
+			Name:       "policy",
+			UID:        uid,
+			Controller: ptr.To(true),
+		}}
+	}
+	existing := func(meta metav1.ObjectMeta) *corev1.Secret {
+		meta.Name = "token"
+		meta.Namespace = "app"
+		meta.ResourceVersion = "1"
+		return &corev1.Secret{ObjectMeta: meta}
+	}
+
+	tests := []struct {
+		name     string
+		policy   v1alpha1.ConflictPolicy
+		target   *corev1.Secret
+		conflict bool
+	}{
+		{
+			name:   "new_secret",
+			policy: v1alpha1.ConflictPolicyFail,
+			target: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "app"}},
+		},
+		{
+			name:   "controlled_by_policy",
+			policy: v1alpha1.ConflictPolicyFail,
+			target: existing(metav1.ObjectMeta{OwnerReferences: ownedBy("uid-1")}),
+		},
+		{
+			name:   "unowned_with_policy_uid",
+			policy: v1alpha1.ConflictPolicyFail,
+			target: existing(metav1.ObjectMeta{Labels: map[string]string{
+				LabelManagedBy:  ID + "-operator",
+				LabelPolicyName: "policy",
+				LabelPolicyUID:  "uid-1",
+			}}),
+		},
+		{
+			name:   "copied_labels_without_uid",
+			policy: v1alpha1.ConflictPolicyFail,
+			target: existing(metav1.ObjectMeta{Labels: map[string]string{
+				LabelManagedBy:  ID + "-operator",
+				LabelPolicyName: "policy",
+			}}),
+			conflict: true,
+		},
+		{
+			name:   "copied_labels_of_other_policy_uid",
+			policy: v1alpha1.ConflictPolicySkip,
+			target: existing(metav1.ObjectMeta{Labels: map[string]string{
+				LabelManagedBy:  ID + "-operator",
+				LabelPolicyName: "policy",
+				LabelPolicyUID:  "uid-2",
+			}}),
+			conflict: true,
+		},
+		{
+			name:   "unmanaged_adopted",
+			policy: v1alpha1.ConflictPolicyAdopt,
+			target: existing(metav1.ObjectMeta{}),
+		},
+		{
+			name:     "unmanaged_skipped",
+			policy:   v1alpha1.ConflictPolicySkip,
+			target:   existing(metav1.ObjectMeta{}),
+			conflict: true,
+		},
+		{
+			name:     "unmanaged_failed",
+			policy:   v1alpha1.ConflictPolicyFail,
+			target:   existing(metav1.ObjectMeta{}),
+			conflict: true,
+		},
+		{
+			name:     "controlled_by_other_owner_never_adopted",
+			policy:   v1alpha1.ConflictPolicyAdopt,
+			target:   existing(metav1.ObjectMeta{OwnerReferences: ownedBy("uid-2")}),
+			conflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity.Spec.ConflictPolicy = tt.policy
+			err := checkTargetConflict(tt.target, "secret", identity)
+			if !tt.conflict {
+				if err != nil {
+					t.Fatalf("checkTargetConflict() = %v, want nil", err)
+				}
+				return
+			}
+			if _, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient); reason != errclass.ReasonTargetConflict {
+				t.Fatalf("checkTargetConflict() reason = %q, want %q", reason, errclass.ReasonTargetConflict)
+			}
+		})
+	}
+}
+
+func TestForEachNamespaceBoundsParallelism(t *testing.T) {
+	namespaces := make([]string, 40)
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("ns-%02d", i)
+	}
+
+	var inFlight, peak, calls atomic.Int32
+	forEachNamespace(namespaces, 4, func(string) {
+		current := inFlight.Add(1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		calls.Add(1)
+		inFlight.Add(-1)
+	})
+
+	if calls.Load() != int32(len(namespaces)) {
+		t.Fatalf("fn called %d times, want %d", calls.Load(), len(namespaces))
+	}
+	if peak.Load() > 4 {
+		t.Fatalf("peak parallelism %d exceeds bound 4", peak.Load())
+	}
+}
+
+func TestObservationConcurrentDeterministic(t *testing.T) {
+	namespaces := make([]string, 30)
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("ns-%02d", i)
+	}
+
+	observe := func() *Observation {
+		shuffled := append([]string(nil), namespaces...)
+		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		obs := NewObservation(len(shuffled), 5)
+		forEachNamespace(shuffled, 8, func(namespace string) {
+			obs.ObserveFailure(namespace, errclass.KindTransient, errclass.ReasonTimeout, errors.New("timeout"))
+		})
+		obs.Sort()
+		return obs
+	}
+
+	for range 10 {
+		obs := observe()
+		if obs.Failed != len(namespaces) || len(obs.Results) != len(namespaces) {
+			t.Fatalf("lost updates: failed=%d results=%d", obs.Failed, len(obs.Results))
+		}
+		for i, res := range obs.Results {
+			if res.Namespace != namespaces[i] {
+				t.Fatalf("results not sorted at %d: %s", i, res.Namespace)
+			}
+		}
+		if len(obs.Samples) != 5 {
+			t.Fatalf("expected 5 samples, got %d", len(obs.Samples))
+		}
+		for i, sample := range obs.Samples {
+			if sample.Namespace != namespaces[i] {
+				t.Fatalf("sample %d = %s, want %s", i, sample.Namespace, namespaces[i])
+			}
+		}
+	}
+}
+
+func TestPolicyDelay(t *testing.T) {
+	base := Policy{TransientDelay: time.Minute, PermanentDelay: 10 * time.Minute}
+	half := func() float64 { return 0.5 }
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		transient bool
+		want      time.Duration
+	}{
+		{name: "transient", policy: base, transient: true, want: time.Minute},
+		{name: "permanent", policy: base, want: 10 * time.Minute},
+		{
+			name: "spec_overrides",
+			policy: base.WithRetryPolicy(v1alpha1.RetryPolicy{
+				TransientDelay: &metav1.Duration{Duration: 5 * time.Second},
+			}),
+			transient: true,
+			want:      5 * time.Second,
+		},
+		{
+			name: "jitter",
+			policy: func() Policy {
+				p := base.WithRetryPolicy(v1alpha1.RetryPolicy{Jitter: ptr.To[int32](20)})
+				p.random = half
+				return p
+			}(),
+			transient: true,
+			want:      66 * time.Second,
+		},
+		{
+			name: "operator_jitter",
+			policy: func() Policy {
+				p := NewPolicy(config.Retry{
+					TransientDelay: metav1.Duration{Duration: time.Minute},
+					Jitter:         20,
+				})
+				p.random = half
+				return p
+			}(),
+			transient: true,
+			want:      66 * time.Second,
+		},
+		{
+			name: "jitter_turned_off",
+			policy: func() Policy {
+				p := NewPolicy(config.Retry{
+					TransientDelay: metav1.Duration{Duration: time.Minute},
+					Jitter:         20,
+				}).WithRetryPolicy(v1alpha1.RetryPolicy{Jitter: ptr.To[int32](0)})
+				p.random = half
+				return p
+			}(),
+			transient: true,
+			want:      time.Minute,
+		},
+		{
+			name: "capped_by_max_backoff",
+			policy: base.WithRetryPolicy(v1alpha1.RetryPolicy{
+				MaxBackoff: &metav1.Duration{Duration: 3 * time.Minute},
+			}),
+			want: 3 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.transient); got != tt.want {
+				t.Fatalf("Delay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureSecretRemovesExtraKeys(t *testing.T) {
+	sch := newTestScheme(t)
+	identity := newSourcePolicy()
+	target := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app-a", Name: "token"},
+		Data:       map[string][]byte{"token": []byte("v1"), "injected": []byte("x")},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(target).Build()
+	src := source{
+		spec:   identity.Spec.Secret,
+		secret: &corev1.Secret{Data: map[string][]byte{"token": []byte("v2")}},
+	}
+
+	action, err := ensureSecret(context.Background(), sch, k8sClient, identity, "app-a", src, false)
+	if err != nil || action != changeUpdate {
+		t.Fatalf("ensureSecret() = %v, %v, want an update", action, err)
+	}
+
+	got := &corev1.Secret{}
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(target), got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Data) != 1 || string(got.Data["token"]) != "v2" {
+		t.Fatalf("data = %v, want only the projected token", got.Data)
+	}
+}
+
+func TestReconcileNamespaceSkipsWritesWhenInSync(t *testing.T) {
+	sch := newTestScheme(t)
+	identity := newSourcePolicy()
+	identity.Spec.ServiceAccount.Name = "sa"
+	var applies int
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithInterceptorFuncs(interceptor.Funcs{
+		Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			applies++
+			return c.Apply(ctx, obj, opts...)
+		},
+	}).Build()
+	src := source{
+		spec:   identity.Spec.Secret,
+		secret: &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: map[string][]byte{"token": []byte("v1")}},
+	}
+
+	if _, err := reconcileNamespace(context.Background(), sch, k8sClient, identity, "app-a", []source{src}, false); err != nil {
+		t.Fatalf("first reconcileNamespace() error = %v", err)
+	}
+	if applies != 2 {
+		t.Fatalf("first run applied %d objects, want the ServiceAccount and the Secret", applies)
+	}
+	if _, err := reconcileNamespace(context.Background(), sch, k8sClient, identity, "app-a", []source{src}, false); err != nil {
+		t.Fatalf("second reconcileNamespace() error = %v", err)
+	}
+	if applies != 2 {
+		t.Fatalf("in-sync namespace was written again: %d applies", applies)
+	}
+}
+
+func TestReconcileNamespaceChecksConflictsBeforeWriting(t *testing.T) {
+	unmanagedSA := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: "app-a"}}
+	unmanagedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "app-a"},
+		Data:       map[string][]byte{"token": []byte("theirs")},
+	}
+	otherOwnerSA := unmanagedSA.DeepCopy()
+	otherOwnerSA.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "other-uid", Controller: ptr.To(true),
+	}}
+
+	tests := []struct {
+		name     string
+		policy   v1alpha1.ConflictPolicy
+		existing []client.Object
+	}{
+		{
+			name:     "unmanaged_secret_skipped",
+			policy:   v1alpha1.ConflictPolicySkip,
+			existing: []client.Object{unmanagedSecret},
+		},
+		{
+			name:     "unmanaged_serviceaccount_failed",
+			policy:   v1alpha1.ConflictPolicyFail,
+			existing: []client.Object{unmanagedSA},
+		},
+		{
+			name:     "serviceaccount_of_other_owner_never_adopted",
+			policy:   v1alpha1.ConflictPolicyAdopt,
+			existing: []client.Object{otherOwnerSA},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sch := newTestScheme(t)
+			identity := newSourcePolicy()
+			identity.Spec.ServiceAccount.Name = "sa"
+			identity.Spec.ConflictPolicy = tt.policy
+			var writes int
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(tt.existing...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+						writes++
+						return c.Apply(ctx, obj, opts...)
+					},
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						writes++
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).Build()
+			src := source{
+				spec:   identity.Spec.Secret,
+				secret: &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: map[string][]byte{"token": []byte("v1")}},
+			}
+
+			_, err := reconcileNamespace(context.Background(), sch, k8sClient, identity, "app-a", []source{src}, false)
+			if _, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient); reason != errclass.ReasonTargetConflict {
+				t.Fatalf("reconcileNamespace() reason = %q (%v), want %q", reason, err, errclass.ReasonTargetConflict)
+			}
+			if writes != 0 {
+				t.Fatalf("conflicting namespace was written %d times, want none", writes)
+			}
+		})
+	}
+}
@@ -4,13 +4,20 @@
 package controller
 
 import (
+	"bytes"
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 )
 
-func shouldFastPath(identity *v1alpha1.IdentitySyncPolicy, currentSecretHash string) bool {
+func shouldFastPath(identity *v1alpha1.IdentitySyncPolicy, currentSecretHash, currentTargetsHash string) bool {
 	generation := identity.GetGeneration()
 	conditions := identity.Status.Conditions
 	if !isCurrentAndEqual(conditions, v1alpha1.ConditionReady, metav1.ConditionTrue, generation) {
@@ -22,12 +29,96 @@ func shouldFastPath(identity *v1alpha1.IdentitySyncPolicy, currentSecretHash str
 	if !isCurrentAndEqual(conditions, v1alpha1.ConditionReferenceSecretReady, metav1.ConditionTrue, generation) {
 		return false
 	}
+	// The fast path returns before status is patched, so a Paused or DryRun
+	// condition left from an earlier reconcile would never be cleared.
+	if meta.IsStatusConditionTrue(conditions, string(v1alpha1.ConditionPaused)) ||
+		meta.IsStatusConditionTrue(conditions, string(v1alpha1.ConditionDryRun)) {
+		return false
+	}
 	if identity.Status.ObservedSourceSecretHash != currentSecretHash {
 		return false
 	}
+	if identity.Status.ObservedTargetsHash != currentTargetsHash {
+		return false
+	}
+	return true
+}
+
+// targetsInSync verifies against the cache that every target namespace still holds
+// the ServiceAccount and a Secret matching each source, both carrying the
+// policy's labels and controller reference, so status alone is never trusted
+// when a target was edited or deleted behind the operator's back.
+func targetsInSync(
+	ctx context.Context,
+	k8sClient client.Reader,
+	identity *v1alpha1.IdentitySyncPolicy,
+	targetNamespaces []string,
+	sources []source,
+) bool {
+	for _, namespace := range targetNamespaces {
+		if state, _, _ := unwritableNamespace(ctx, k8sClient, namespace); state != "" {
+			if state == v1alpha1.TargetStateNamespaceMissing && identity.Spec.CreateNamespaces.Enabled {
+				return false
+			}
+			// Nothing is written into missing or terminating namespaces.
+			continue
+		}
+		saKey := types.NamespacedName{Namespace: namespace, Name: identity.Spec.ServiceAccount.Name}
+		serviceAccount := &corev1.ServiceAccount{}
+		if err := k8sClient.Get(ctx, saKey, serviceAccount); err != nil {
+			return false
+		}
+		if !targetMetadataInSync(serviceAccount, identity) {
+			return false
+		}
+		for _, src := range sources {
+			if !targetSecretInSync(ctx, k8sClient, identity, namespace, src) {
+				return false
+			}
+		}
+	}
 	return true
 }
 
+func targetSecretInSync(
+	ctx context.Context,
+	k8sClient client.Reader,
+	identity *v1alpha1.IdentitySyncPolicy,
+	namespace string,
+	src source,
+) bool {
+	secretKey := types.NamespacedName{Namespace: namespace, Name: src.spec.Name}
+	target := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, secretKey, target); err != nil {
+		return false
+	}
+	if identity.Spec.ConflictPolicy == v1alpha1.ConflictPolicySkip && !isOwnTarget(target, identity) {
+		return true
+	}
+	desired, err := desiredSecretData(src.spec, src.secret, namespace)
+	if err != nil {
+		return false
+	}
+	return hasManagedMetadata(target, identity) &&
+		dataEqual(target.Data, desired) &&
+		target.Type == projectSecretType(src.secret.Type, desired)
+}
+
+// targetMetadataInSync reports whether obj still carries the policy's labels and
+// controller reference. An object the policy skips as a conflict is left as is.
+func targetMetadataInSync(obj metav1.Object, identity *v1alpha1.IdentitySyncPolicy) bool {
+	if identity.Spec.ConflictPolicy == v1alpha1.ConflictPolicySkip && !isOwnTarget(obj, identity) {
+		return true
+	}
+	return hasManagedMetadata(obj, identity)
+}
+
+// dataEqual reports whether data holds exactly the desired keys and values, so
+// a key added to a target is detected as drift like a changed one.
+func dataEqual(data, desired map[string][]byte) bool {
+	return maps.EqualFunc(data, desired, bytes.Equal)
+}
+
 func isCurrentAndEqual(
 	conditions []metav1.Condition,
 	condType v1alpha1.ConditionType,
@@ -4,9 +4,14 @@
 package controller
 
 import (
+	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 )
@@ -21,10 +26,13 @@ func cond(t string, s metav1.ConditionStatus, gen int64) metav1.Condition {
 	}
 }
 
+const targetsA = "targetsA"
+
 func identityWith(gen int64, observedHash string, conditions ...metav1.Condition) *v1alpha1.IdentitySyncPolicy {
 	identity := &v1alpha1.IdentitySyncPolicy{}
 	identity.SetGeneration(gen)
 	identity.Status.ObservedSourceSecretHash = observedHash
+	identity.Status.ObservedTargetsHash = targetsA
 	identity.Status.Conditions = conditions
 	return identity
 }
@@ -34,10 +42,11 @@ func TestShouldFastPath(t *testing.T) {
 	const hashB = "hashB"
 
 	tests := []struct {
-		name        string
-		identity    *v1alpha1.IdentitySyncPolicy
-		currentHash string
-		want        bool
+		name           string
+		identity       *v1alpha1.IdentitySyncPolicy
+		currentHash    string
+		currentTargets string
+		want           bool
 	}{
 		{
 			name: "true_when_ready_and_prereqs_ok_for_current_generation",
@@ -123,14 +132,114 @@ func TestShouldFastPath(t *testing.T) {
 			currentHash: hashB,
 			want:        false,
 		},
+		{
+			name: "false_when_paused_not_cleared",
+			identity: identityWith(7, hashA,
+				cond("Ready", metav1.ConditionTrue, 7),
+				cond("Degraded", metav1.ConditionFalse, 7),
+				cond("ReferenceSecretReady", metav1.ConditionTrue, 7),
+				cond("Paused", metav1.ConditionTrue, 7),
+			),
+			currentHash: hashA,
+			want:        false,
+		},
+		{
+			name: "false_when_dry_run_not_cleared",
+			identity: identityWith(7, hashA,
+				cond("Ready", metav1.ConditionTrue, 7),
+				cond("Degraded", metav1.ConditionFalse, 7),
+				cond("ReferenceSecretReady", metav1.ConditionTrue, 7),
+				cond("DryRun", metav1.ConditionTrue, 7),
+			),
+			currentHash: hashA,
+			want:        false,
+		},
+		{
+			name: "false_when_targets_hash_mismatch",
+			identity: identityWith(7, hashA,
+				cond("Ready", metav1.ConditionTrue, 7),
+				cond("Degraded", metav1.ConditionFalse, 7),
+				cond("ReferenceSecretReady", metav1.ConditionTrue, 7),
+			),
+			currentHash:    hashA,
+			currentTargets: "targetsB",
+			want:           false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := shouldFastPath(tt.identity, tt.currentHash)
+			currentTargets := tt.currentTargets
+			if currentTargets == "" {
+				currentTargets = targetsA
+			}
+			got := shouldFastPath(tt.identity, tt.currentHash, currentTargets)
 			if got != tt.want {
 				t.Fatalf("shouldFastPath()=%v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestTargetsInSync(t *testing.T) {
+	sch := newTestScheme(t)
+	identity := newSourcePolicy()
+	identity.Spec.ServiceAccount.Name = "sa"
+	src := source{
+		spec:   identity.Spec.Secret,
+		secret: &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: map[string][]byte{"token": []byte("v1")}},
+	}
+	managed := func(obj client.Object) {
+		obj.SetLabels(managedMetadataLabels(identity))
+		if err := controllerutil.SetControllerReference(identity, obj, sch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stripLabels := func(obj client.Object) { obj.SetLabels(nil) }
+	dropOwner := func(obj client.Object) { obj.SetOwnerReferences(nil) }
+
+	tests := []struct {
+		name       string
+		data       map[string][]byte
+		secretMeta func(client.Object)
+		saMeta     func(client.Object)
+		want       bool
+	}{
+		{name: "matching_data", data: map[string][]byte{"token": []byte("v1")}, want: true},
+		{name: "changed_key", data: map[string][]byte{"token": []byte("v0")}},
+		{name: "injected_key", data: map[string][]byte{"token": []byte("v1"), "injected": []byte("x")}},
+		{name: "secret_labels_stripped", data: map[string][]byte{"token": []byte("v1")}, secretMeta: stripLabels},
+		{name: "secret_owner_removed", data: map[string][]byte{"token": []byte("v1")}, secretMeta: dropOwner},
+		{name: "service_account_labels_stripped", data: map[string][]byte{"token": []byte("v1")}, saMeta: stripLabels},
+		{name: "service_account_owner_removed", data: map[string][]byte{"token": []byte("v1")}, saMeta: dropOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "app-a", Name: "sa"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app-a", Name: "token"},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       tt.data,
+			}
+			managed(serviceAccount)
+			managed(secret)
+			if tt.saMeta != nil {
+				tt.saMeta(serviceAccount)
+			}
+			if tt.secretMeta != nil {
+				tt.secretMeta(secret)
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-a"}},
+				serviceAccount,
+				secret,
+			).Build()
+
+			got := targetsInSync(context.Background(), k8sClient, identity, []string{"app-a"}, []source{src})
+			if got != tt.want {
+				t.Fatalf("targetsInSync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
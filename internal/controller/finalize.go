@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+	"github.com/lapacek-labs/identity-operator/pkg/status"
+)
+
+// ensureFinalizer adds the policy finalizer so targets are released according to
+// spec.deletionPolicy instead of being garbage-collected through ownerReferences.
+func (c *Controller) ensureFinalizer(ctx context.Context, identity *v1alpha1.IdentitySyncPolicy) error {
+	if controllerutil.ContainsFinalizer(identity, Finalizer) {
+		return nil
+	}
+	base := identity.DeepCopy()
+	controllerutil.AddFinalizer(identity, Finalizer)
+	return c.client.Patch(ctx, identity, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+}
+
+// finalize releases every target of a policy being deleted and removes the
+// finalizer once all of them are released. Failures keep the finalizer, are
+// reported on the policy conditions and retried.
+func (c *Controller) finalize(
+	ctx context.Context,
+	identity *v1alpha1.IdentitySyncPolicy,
+	conditionSet *status.ConditionSet,
+	startTime time.Time,
+) (controllerruntime.Result, error) {
+	if !controllerutil.ContainsFinalizer(identity, Finalizer) {
+		return controllerruntime.Result{}, nil
+	}
+
+	cfg := c.tuning()
+	observation := releaseTargets(ctx, c.scheme, c.client, identity, cfg.Fanout.MaxSamples)
+	decision := NewPolicy(cfg.Retry).WithRetryPolicy(identity.Spec.RetryPolicy).Decide(observation)
+
+	if decision.Outcome != result.OutcomeSuccess {
+		decision.Msg = "failed releasing targets"
+		return c.finish(ctx, reconcileContext{
+			phase:       observability.PhaseFinalize,
+			identity:    identity,
+			conditions:  conditionSet,
+			observation: observation,
+			decision:    decision,
+			start:       startTime,
+		})
+	}
+
+	base := identity.DeepCopy()
+	controllerutil.RemoveFinalizer(identity, Finalizer)
+	if err := c.client.Patch(ctx, identity, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		if apierrors.IsNotFound(err) {
+			return controllerruntime.Result{}, nil
+		}
+		return controllerruntime.Result{}, err
+	}
+
+	// The policy may be gone by now, so conditions are not patched.
+	decision.Msg = "targets released"
+	return c.finish(ctx, reconcileContext{
+		phase:       observability.PhaseFinalize,
+		identity:    identity,
+		observation: observation,
+		decision:    decision,
+		start:       startTime,
+	})
+}
+
+// releaseTargets applies spec.deletionPolicy to every target controlled by the
+// policy and to the namespaces it created.
+func releaseTargets(
+	ctx context.Context,
+	k8sScheme *runtime.Scheme,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	maxSample int,
+) *Observation {
+	targets, err := listManagedTargets(ctx, k8sClient, identity)
+	if err != nil {
+		observation := NewObservation(1, maxSample)
+		kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+		observation.ObserveFailure("", kind, reason, err)
+		return observation
+	}
+	namespaces, err := listCreatedNamespaces(ctx, k8sClient, identity)
+	if err != nil {
+		observation := NewObservation(1, maxSample)
+		kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+		observation.ObserveFailure("", kind, reason, err)
+		return observation
+	}
+
+	observation := NewObservation(len(targets)+len(namespaces), maxSample)
+	for _, target := range targets {
+		if err := releaseTarget(ctx, k8sScheme, k8sClient, identity, target); err != nil {
+			kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+			observation.ObserveFailure(target.GetNamespace(), kind, reason, err)
+			continue
+		}
+		observation.ObserveSuccess(target.GetNamespace())
+	}
+	// Namespaces go last, so their content is released before they are deleted.
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		if err := releaseNamespace(ctx, k8sClient, identity, namespace); err != nil {
+			kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+			observation.ObserveFailure(namespace.Name, kind, reason, err)
+			continue
+		}
+		observation.ObserveSuccess(namespace.Name)
+	}
+	return observation
+}
+
+// releaseNamespace deletes a namespace created by the policy under the Delete
+// policy and strips its management labels and annotation otherwise. A labelled
+// namespace the policy did not create is never deleted.
+func releaseNamespace(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	namespace *corev1.Namespace,
+) error {
+	if identity.Spec.DeletionPolicy != v1alpha1.DeletionPolicyOrphan && isCreatedNamespace(namespace, identity) {
+		return client.IgnoreNotFound(k8sClient.Delete(ctx, namespace))
+	}
+	base := namespace.DeepCopy()
+	for _, label := range managedLabels {
+		delete(namespace.Labels, label)
+	}
+	if namespace.Annotations[AnnotationCreatedBy] == string(identity.UID) {
+		delete(namespace.Annotations, AnnotationCreatedBy)
+	}
+	return client.IgnoreNotFound(k8sClient.Patch(ctx, namespace, client.MergeFrom(base)))
+}
+
+func releaseTarget(
+	ctx context.Context,
+	k8sScheme *runtime.Scheme,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	target client.Object,
+) error {
+	switch {
+	case identity.Spec.DeletionPolicy == v1alpha1.DeletionPolicyOrphan || isSourceSecret(identity, target):
+		// A source Secret adopted as a target is never deleted.
+		return orphanTarget(ctx, k8sScheme, k8sClient, identity, target)
+	default:
+		return client.IgnoreNotFound(k8sClient.Delete(ctx, target))
+	}
+}
+
+// orphanTarget removes the policy's controller reference from target, so it is
+// not garbage-collected with the policy, and its management labels.
+func orphanTarget(
+	ctx context.Context,
+	k8sScheme *runtime.Scheme,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	target client.Object,
+) error {
+	base := target.DeepCopyObject().(client.Object)
+	if err := controllerutil.RemoveControllerReference(identity, target, k8sScheme); err != nil {
+		return err
+	}
+	labels := target.GetLabels()
+	for _, label := range managedLabels {
+		delete(labels, label)
+	}
+	target.SetLabels(labels)
+	return client.IgnoreNotFound(k8sClient.Patch(ctx, target, client.MergeFrom(base)))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestReleaseTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      v1alpha1.DeletionPolicy
+		namespace   string
+		wantDeleted bool
+	}{
+		{name: "delete", policy: v1alpha1.DeletionPolicyDelete, namespace: "app-a", wantDeleted: true},
+		{name: "orphan", policy: v1alpha1.DeletionPolicyOrphan, namespace: "app-a"},
+		{name: "source_never_deleted", policy: v1alpha1.DeletionPolicyDelete, namespace: "vault"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sch := newTestScheme(t)
+			identity := newSourcePolicy()
+			identity.Spec.DeletionPolicy = tt.policy
+			target := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Namespace: tt.namespace,
+				Name:      "token",
+				Labels:    managedMetadataLabels(identity),
+			}}
+			if err := controllerutil.SetControllerReference(identity, target, sch); err != nil {
+				t.Fatal(err)
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(target).Build()
+
+			if err := releaseTarget(context.Background(), sch, k8sClient, identity, target); err != nil {
+				t.Fatalf("releaseTarget() error = %v", err)
+			}
+
+			got := &corev1.Secret{}
+			err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(target), got)
+			if tt.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("target still exists: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("target was deleted: %v", err)
+			}
+			if len(got.OwnerReferences) != 0 || isManagedTarget(got) {
+				t.Fatalf("target still managed: owners %v, labels %v", got.OwnerReferences, got.Labels)
+			}
+		})
+	}
+}
+
+func TestReleaseNamespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      v1alpha1.DeletionPolicy
+		createdBy   string
+		wantDeleted bool
+	}{
+		{name: "delete_created", policy: v1alpha1.DeletionPolicyDelete, createdBy: "policy-uid", wantDeleted: true},
+		{name: "orphan_created", policy: v1alpha1.DeletionPolicyOrphan, createdBy: "policy-uid"},
+		{name: "label_only_never_deleted", policy: v1alpha1.DeletionPolicyDelete},
+		{name: "created_by_other_policy", policy: v1alpha1.DeletionPolicyDelete, createdBy: "other-uid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := newSourcePolicy()
+			identity.Spec.DeletionPolicy = tt.policy
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "app-a",
+				Labels: managedMetadataLabels(identity),
+			}}
+			if tt.createdBy != "" {
+				namespace.Annotations = map[string]string{AnnotationCreatedBy: tt.createdBy}
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(namespace).Build()
+
+			if err := releaseNamespace(context.Background(), k8sClient, identity, namespace); err != nil {
+				t.Fatalf("releaseNamespace() error = %v", err)
+			}
+
+			got := &corev1.Namespace{}
+			err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(namespace), got)
+			if tt.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("namespace still exists: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("namespace was deleted: %v", err)
+			}
+			if got.Labels[LabelPolicyUID] != "" || got.Annotations[AnnotationCreatedBy] == string(identity.UID) {
+				t.Fatalf("namespace still marked: labels %v, annotations %v", got.Labels, got.Annotations)
+			}
+		})
+	}
+}
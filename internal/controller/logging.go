@@ -16,6 +16,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/config"
 	"github.com/lapacek-labs/identity-operator/pkg/logging"
 	"github.com/lapacek-labs/identity-operator/pkg/observability"
 	"github.com/lapacek-labs/identity-operator/pkg/result"
@@ -24,6 +25,7 @@ import (
 func logOperationIfAllowed(
 	ctx context.Context,
 	limiter *logging.Limiter,
+	intervals config.Logging,
 	phase observability.Phase,
 	identity *v1alpha1.IdentitySyncPolicy,
 	decision result.Decision,
@@ -33,7 +35,7 @@ func logOperationIfAllowed(
 	if identity == nil {
 		return
 	}
-	if decision.Outcome == result.OutcomeSuccess {
+	if decision.Outcome == result.OutcomeSuccess || decision.Outcome == result.OutcomeSkipped {
 		return
 	}
 
@@ -66,31 +68,18 @@ func logOperationIfAllowed(
 	}
 
 	now := time.Now()
-	interval := reminderInterval(primary)
+	interval := intervals.ReminderInterval(primary)
 	fpReminder := fmt.Sprintf("fail|%s|%s|%s", identity.UID, phase, primary)
 	fpChange := fmt.Sprintf("chg|%s|%s|%s|%s|%s", identity.UID, phase, decision.Outcome, reasonsKey, samplesHash)
 
 	// Log if either:
 	// - reminder interval elapsed, OR
 	// - content changed (short throttle so we don't spam on flapping)
-	if limiter.Allow(fpReminder, now, interval) || limiter.Allow(fpChange, now, 30*time.Second) {
+	if limiter.Allow(fpReminder, now, interval) || limiter.Allow(fpChange, now, intervals.ChangeInterval.Duration) {
 		logFailure(logger, phase, identity, decision, observation, "reminder")
 	}
 }
 
-func reminderInterval(r result.Reason) time.Duration {
-	switch r {
-	case result.ReasonNotFound:
-		return 20 * time.Minute
-	case result.ReasonForbidden, result.ReasonInvalidSpec:
-		return 5 * time.Minute
-	case result.ReasonTimeout, result.ReasonAPIServerError, result.ReasonConflict:
-		return 2 * time.Minute
-	default:
-		return 10 * time.Minute
-	}
-}
-
 func logFailure(
 	logger logr.Logger,
 	phase observability.Phase,
@@ -113,7 +102,10 @@ func logFailure(
 		kv = append(kv,
 			"success", observation.Success,
 			"failed", observation.Failed,
+			"backedOff", observation.BackedOff,
 			"total", observation.Total,
+			"pruned", observation.Pruned,
+			"pruneFailed", observation.PruneFailed,
 			"hasTransient", observation.HasTransient,
 			"hasPermanent", observation.HasPermanent,
 			"reasons", formatReasons(observation.ErrorReasonCounts()),
@@ -4,7 +4,9 @@
 package controller
 
 import (
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 )
@@ -15,19 +17,45 @@ const (
 
 	LabelPolicyName = "identitysyncpolicy.platform.lapacek-labs.org/policy-name"
 	LabelPolicyUID  = "identitysyncpolicy.platform.lapacek-labs.org/policy-uid"
+
+	// AnnotationCreatedBy holds the UID of the policy that created a namespace.
+	// Together with LabelPolicyUID it is what allows the namespace to be deleted.
+	AnnotationCreatedBy = "identitysyncpolicy.platform.lapacek-labs.org/created-by"
+
+	Finalizer = "identitysyncpolicy.platform.lapacek-labs.org/finalizer"
 )
 
-func ensureManagedMetadata(meta *metav1.ObjectMeta, identity *v1alpha1.IdentitySyncPolicy) {
-	if meta == nil || identity == nil {
-		return
-	}
-	if meta.Labels == nil {
-		meta.Labels = map[string]string{}
-	}
+// FieldManager is the server-side apply field manager owning the fields the
+// operator sets on target objects.
+const FieldManager = "identity-sync-operator"
 
-	meta.Labels[LabelName] = ID
-	meta.Labels[LabelManagedBy] = ID + "-operator"
+// managedLabels are the labels managedMetadataLabels sets on every target.
+var managedLabels = []string{LabelName, LabelManagedBy, LabelPolicyName, LabelPolicyUID}
 
-	meta.Labels[LabelPolicyName] = identity.Name
-	meta.Labels[LabelPolicyUID] = string(identity.UID)
+func managedMetadataLabels(identity *v1alpha1.IdentitySyncPolicy) map[string]string {
+	return map[string]string{
+		LabelName:       ID,
+		LabelManagedBy:  ID + "-operator",
+		LabelPolicyName: identity.Name,
+		LabelPolicyUID:  string(identity.UID),
+	}
+}
+
+// controllerReference is the apply configuration equivalent of
+// controllerutil.SetControllerReference.
+func controllerReference(
+	identity *v1alpha1.IdentitySyncPolicy,
+	k8sScheme *runtime.Scheme,
+) (*metav1ac.OwnerReferenceApplyConfiguration, error) {
+	gvk, err := apiutil.GVKForObject(identity, k8sScheme)
+	if err != nil {
+		return nil, err
+	}
+	return metav1ac.OwnerReference().
+		WithAPIVersion(gvk.GroupVersion().String()).
+		WithKind(gvk.Kind).
+		WithName(identity.Name).
+		WithUID(identity.UID).
+		WithController(true).
+		WithBlockOwnerDeletion(true), nil
 }
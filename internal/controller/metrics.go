@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+)
+
+// policyMetrics describes the persisted status of a policy for the policy gauges.
+func policyMetrics(identity *v1alpha1.IdentitySyncPolicy) observability.Policy {
+	state := observability.PolicyUnknown
+	switch {
+	case meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionDegraded)):
+		state = observability.PolicyDegraded
+	case meta.IsStatusConditionTrue(identity.Status.Conditions, string(v1alpha1.ConditionReady)):
+		state = observability.PolicyReady
+	}
+	var outOfSync []string
+	for _, target := range identity.Status.Targets {
+		if target.State == v1alpha1.TargetStateFailed {
+			outOfSync = append(outOfSync, target.Namespace)
+		}
+	}
+	return observability.Policy{
+		Key:                 client.ObjectKeyFromObject(identity).String(),
+		State:               state,
+		OutOfSync:           int(identity.Status.Failed),
+		OutOfSyncNamespaces: outOfSync,
+	}
+}
+
+// propagationLatencies returns, for every source whose change a successful
+// fan-out is about to record, the time since its source Secret was last
+// written. It must be called before the status is patched.
+//
+// --- What is left out ---
+// A source synced for the first time, or re-synced because the policy spec
+// changed, was not changed by a write to the source Secret; the time since
+// that write says nothing about propagation.
+func propagationLatencies(identity *v1alpha1.IdentitySyncPolicy, sources []source, now time.Time) []time.Duration {
+	ready := meta.FindStatusCondition(identity.Status.Conditions, string(v1alpha1.ConditionReady))
+	if ready == nil || ready.ObservedGeneration != identity.GetGeneration() {
+		return nil
+	}
+	prevHashes := make(map[string]string, len(identity.Status.Secrets))
+	for _, st := range identity.Status.Secrets {
+		prevHashes[st.Name] = st.ObservedHash
+	}
+	var latencies []time.Duration
+	for _, src := range sources {
+		prev := prevHashes[src.spec.Name]
+		if prev == "" || prev == src.hash {
+			continue
+		}
+		latencies = append(latencies, max(now.Sub(sourceChangeTime(src.secret)), 0))
+	}
+	return latencies
+}
+
+// sourceChangeTime returns when the Secret was last written, taken from its
+// managed fields, or its creation time when they are not tracked.
+func sourceChangeTime(secret *corev1.Secret) time.Time {
+	latest := secret.CreationTimestamp.Time
+	for _, entry := range secret.ManagedFields {
+		if entry.Time != nil && entry.Time.After(latest) {
+			latest = entry.Time.Time
+		}
+	}
+	return latest
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestPropagationLatencies(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	changed := metav1.NewTime(now.Add(-90 * time.Second))
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl", Time: &changed},
+		},
+	}}
+	sources := []source{{spec: v1alpha1.Secret{Name: "token"}, secret: secret, hash: "new"}}
+
+	policy := func(generation, readyGeneration int64, prevHash string) *v1alpha1.IdentitySyncPolicy {
+		return &v1alpha1.IdentitySyncPolicy{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Status: v1alpha1.IdentitySyncPolicyStatus{
+				Conditions: []metav1.Condition{{
+					Type:               string(v1alpha1.ConditionReady),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: readyGeneration,
+				}},
+				Secrets: []v1alpha1.SecretStatus{{Name: "token", Available: true, ObservedHash: prevHash}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		identity *v1alpha1.IdentitySyncPolicy
+		want     []time.Duration
+	}{
+		{name: "source_changed", identity: policy(2, 2, "old"), want: []time.Duration{90 * time.Second}},
+		{name: "unchanged", identity: policy(2, 2, "new")},
+		{name: "first_sync", identity: policy(2, 2, "")},
+		{name: "spec_changed", identity: policy(3, 2, "old")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := propagationLatencies(tt.identity, sources, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("propagationLatencies() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("propagationLatencies() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyMetricsCountsEveryFailedNamespace(t *testing.T) {
+	identity := &v1alpha1.IdentitySyncPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+		Status: v1alpha1.IdentitySyncPolicyStatus{
+			Failed:  3,
+			Targets: []v1alpha1.TargetStatus{{Namespace: "app-a", State: v1alpha1.TargetStateFailed}},
+		},
+	}
+
+	got := policyMetrics(identity)
+	if got.OutOfSync != 3 || len(got.OutOfSyncNamespaces) != 1 {
+		t.Fatalf("policyMetrics() = %+v, want 3 out of sync with app-a listed", got)
+	}
+}
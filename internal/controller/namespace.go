@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
+)
+
+// unwritableNamespace reports the state of a target namespace that cannot be
+// written into, or an empty state when it can. The Namespace watch enqueues the
+// policy again once a missing namespace is created.
+func unwritableNamespace(
+	ctx context.Context,
+	k8sClient client.Reader,
+	namespace string,
+) (v1alpha1.TargetState, errclass.ErrorReason, error) {
+	ns := &corev1.Namespace{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns)
+	switch {
+	case apierrors.IsNotFound(err):
+		return v1alpha1.TargetStateNamespaceMissing, errclass.ReasonNotFound, err
+	case err != nil:
+		// Let the writes surface the error.
+		return "", "", nil
+	case ns.Status.Phase == corev1.NamespaceTerminating || !ns.DeletionTimestamp.IsZero():
+		return v1alpha1.TargetStateTerminating, errclass.ReasonNamespaceTerminating,
+			fmt.Errorf("namespace %s is terminating", namespace)
+	}
+	return "", "", nil
+}
+
+// shouldCreateNamespace reports whether a missing namespace is created by the
+// policy. Only explicitly listed, unprotected namespaces are created.
+func shouldCreateNamespace(identity *v1alpha1.IdentitySyncPolicy, namespace string, protected guard.Namespaces) bool {
+	return identity.Spec.CreateNamespaces.Enabled &&
+		slices.Contains(identity.Spec.TargetNamespaces, namespace) &&
+		!protected.Protected(namespace)
+}
+
+// createTargetNamespace creates a target namespace carrying the policy UID label
+// and created-by annotation, which together later identify it as created by the
+// policy. Existing namespaces are never modified.
+func createTargetNamespace(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	namespace string,
+	dryRun bool,
+) error {
+	spec := identity.Spec.CreateNamespaces
+	labels := maps.Clone(spec.Labels)
+	if labels == nil {
+		labels = make(map[string]string, len(managedLabels))
+	}
+	maps.Copy(labels, managedMetadataLabels(identity))
+
+	annotations := maps.Clone(spec.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[AnnotationCreatedBy] = string(identity.UID)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+	var opts []client.CreateOption
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := k8sClient.Create(ctx, ns, opts...); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// listCreatedNamespaces returns the namespaces carrying the policy UID label.
+// Only those isCreatedNamespace accepts were created by the policy.
+func listCreatedNamespaces(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+) ([]corev1.Namespace, error) {
+	var list corev1.NamespaceList
+	if err := k8sClient.List(ctx, &list, client.MatchingLabels{LabelPolicyUID: string(identity.UID)}); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// isCreatedNamespace reports whether the policy created namespace. The label
+// alone can be copied onto any namespace, and deleting a namespace deletes
+// everything in it, so the created-by annotation must name the policy too.
+func isCreatedNamespace(namespace *corev1.Namespace, identity *v1alpha1.IdentitySyncPolicy) bool {
+	return namespace.Labels[LabelPolicyUID] == string(identity.UID) &&
+		namespace.Annotations[AnnotationCreatedBy] == string(identity.UID)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
+)
+
+func TestShouldCreateNamespace(t *testing.T) {
+	protected := guard.NewNamespaces([]string{"kube-system"})
+	identity := func(enabled bool) *v1alpha1.IdentitySyncPolicy {
+		return &v1alpha1.IdentitySyncPolicy{
+			Spec: v1alpha1.IdentitySyncPolicySpec{
+				TargetNamespaces: []string{"app-1", "kube-system"},
+				CreateNamespaces: v1alpha1.CreateNamespaces{Enabled: enabled},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		identity  *v1alpha1.IdentitySyncPolicy
+		namespace string
+		want      bool
+	}{
+		{name: "listed_namespace", identity: identity(true), namespace: "app-1", want: true},
+		{name: "disabled", identity: identity(false), namespace: "app-1"},
+		{name: "selector_only_namespace", identity: identity(true), namespace: "app-2"},
+		{name: "protected_namespace", identity: identity(true), namespace: "kube-system"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldCreateNamespace(tt.identity, tt.namespace, protected); got != tt.want {
+				t.Fatalf("shouldCreateNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
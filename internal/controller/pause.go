@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+// PauseKey is the data key of the pause ConfigMap. The value "true" pauses
+// every policy; any other value or a missing ConfigMap resumes them.
+const PauseKey = "paused"
+
+// paused reports whether the operator-wide pause switch is on.
+func paused(ctx context.Context, k8sClient client.Reader, ref types.NamespacedName) (bool, error) {
+	if ref.Name == "" {
+		return false, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, ref, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return configMap.Data[PauseKey] == "true", nil
+}
+
+// isPauseConfigMap passes events for the pause ConfigMap only.
+func isPauseConfigMap(ref types.NamespacedName) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == ref.Namespace && obj.GetName() == ref.Name
+	})
+}
+
+// mapPauseToIdentities enqueues every policy, so flipping the switch is
+// reflected on all of them at once.
+func mapPauseToIdentities(ctx context.Context, k8sClient client.Client, _ client.Object) []reconcile.Request {
+	var list v1alpha1.IdentitySyncPolicyList
+	if err := k8sClient.List(ctx, &list); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list identity sync policy", "handler", "mapPauseToIdentities")
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		cr := &list.Items[i]
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+		})
+	}
+	return reqs
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+// projectSecretData returns the source data as it is written to targets:
+// filtered by include/exclude, then with renamed keys applied.
+// The source map is never modified.
+func projectSecretData(keys *v1alpha1.SecretKeys, data map[string][]byte) map[string][]byte {
+	if keys == nil {
+		return data
+	}
+
+	included := func(key string) bool {
+		if len(keys.Include) > 0 && !slices.Contains(keys.Include, key) {
+			return false
+		}
+		return !slices.Contains(keys.Exclude, key)
+	}
+
+	renamed := make(map[string]string, len(keys.Rename))
+	for _, rename := range keys.Rename {
+		renamed[rename.From] = rename.To
+	}
+
+	projected := make(map[string][]byte, len(data))
+	for key, value := range data {
+		if !included(key) {
+			continue
+		}
+		if _, ok := renamed[key]; ok {
+			continue
+		}
+		projected[key] = value
+	}
+	// Renames are applied last so they deterministically win over copied keys.
+	for key, value := range data {
+		if to, ok := renamed[key]; ok && included(key) {
+			projected[to] = value
+		}
+	}
+	return projected
+}
+
+// desiredSecretData returns the data a target Secret in namespace must hold:
+// the projected source data plus rendered templates.
+func desiredSecretData(
+	spec v1alpha1.Secret,
+	sourceSecret *corev1.Secret,
+	namespace string,
+) (map[string][]byte, error) {
+	data := projectSecretData(spec.Keys, sourceSecret.Data)
+	if len(spec.Template) == 0 {
+		return data, nil
+	}
+	rendered, err := renderTemplates(spec.Template, sourceSecret.Data, namespace)
+	if err != nil {
+		return nil, err
+	}
+	data = maps.Clone(data)
+	if data == nil {
+		data = make(map[string][]byte, len(rendered))
+	}
+	maps.Copy(data, rendered)
+	return data, nil
+}
+
+// sourceFingerprint hashes everything in the source Secret that targets depend on.
+// Templates may read keys that are filtered out, so with templates the whole
+// source data is fingerprinted.
+func sourceFingerprint(spec v1alpha1.Secret, sourceSecret *corev1.Secret) string {
+	if len(spec.Template) > 0 {
+		return dataHash(sourceSecret.Data)
+	}
+	return dataHash(projectSecretData(spec.Keys, sourceSecret.Data))
+}
+
+// requiredSecretKeys are the keys the API server requires for built-in Secret types.
+var requiredSecretKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+}
+
+// projectSecretType keeps the source type unless the projected data no longer
+// holds a key the type requires, in which case targets are written as Opaque.
+func projectSecretType(sourceType corev1.SecretType, data map[string][]byte) corev1.SecretType {
+	for _, key := range requiredSecretKeys[sourceType] {
+		if _, ok := data[key]; !ok {
+			return corev1.SecretTypeOpaque
+		}
+	}
+	return sourceType
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"maps"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestProjectSecretData(t *testing.T) {
+	source := map[string][]byte{
+		"tls.crt": []byte("crt"),
+		"tls.key": []byte("key"),
+		"ca.crt":  []byte("ca"),
+	}
+
+	tests := []struct {
+		name string
+		keys *v1alpha1.SecretKeys
+		want map[string][]byte
+	}{
+		{
+			name: "unset_copies_everything",
+			want: source,
+		},
+		{
+			name: "include",
+			keys: &v1alpha1.SecretKeys{Include: []string{"ca.crt", "missing"}},
+			want: map[string][]byte{"ca.crt": []byte("ca")},
+		},
+		{
+			name: "exclude",
+			keys: &v1alpha1.SecretKeys{Exclude: []string{"tls.key"}},
+			want: map[string][]byte{"tls.crt": []byte("crt"), "ca.crt": []byte("ca")},
+		},
+		{
+			name: "rename_filtered_by_source_name",
+			keys: &v1alpha1.SecretKeys{
+				Include: []string{"ca.crt"},
+				Rename: []v1alpha1.KeyRename{
+					{From: "ca.crt", To: "ca.pem"},
+					{From: "tls.key", To: "key.pem"},
+				},
+			},
+			want: map[string][]byte{"ca.pem": []byte("ca")},
+		},
+		{
+			name: "rename_wins_over_copied_key",
+			keys: &v1alpha1.SecretKeys{
+				Rename: []v1alpha1.KeyRename{{From: "ca.crt", To: "tls.crt"}},
+			},
+			want: map[string][]byte{"tls.crt": []byte("ca"), "tls.key": []byte("key")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectSecretData(tt.keys, source)
+			if !maps.EqualFunc(got, tt.want, func(a, b []byte) bool { return string(a) == string(b) }) {
+				t.Fatalf("projectSecretData() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if len(source) != 3 {
+		t.Fatalf("source data was modified: %q", source)
+	}
+}
+
+func TestProjectSecretType(t *testing.T) {
+	full := map[string][]byte{corev1.TLSCertKey: nil, corev1.TLSPrivateKeyKey: nil}
+	caOnly := map[string][]byte{"ca.crt": nil}
+
+	if got := projectSecretType(corev1.SecretTypeTLS, full); got != corev1.SecretTypeTLS {
+		t.Fatalf("projectSecretType(tls, full) = %q, want %q", got, corev1.SecretTypeTLS)
+	}
+	if got := projectSecretType(corev1.SecretTypeTLS, caOnly); got != corev1.SecretTypeOpaque {
+		t.Fatalf("projectSecretType(tls, ca only) = %q, want %q", got, corev1.SecretTypeOpaque)
+	}
+	if got := projectSecretType(corev1.SecretTypeBasicAuth, caOnly); got != corev1.SecretTypeBasicAuth {
+		t.Fatalf("projectSecretType(basic-auth) = %q, want %q", got, corev1.SecretTypeBasicAuth)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+// propagationSummary replaces status.pendingPropagation and, when last is set,
+// status.lastPropagation.
+type propagationSummary struct {
+	pending *v1alpha1.Propagation
+	last    *v1alpha1.Propagation
+}
+
+func (p propagationSummary) applyTo(st *v1alpha1.IdentitySyncPolicyStatus) {
+	st.PendingPropagation = p.pending
+	if p.last != nil {
+		st.LastPropagation = p.last
+	}
+}
+
+// trackPropagation follows the source hash from the reconcile that first
+// observed it until a fan-out synced it everywhere.
+//
+// --- Partial fan-outs ---
+// The pending start time is only set once: partial fan-outs and further source
+// changes keep it, so the completed duration is measured from the oldest change
+// that had not reached every target namespace.
+//
+// --- First sync ---
+// The first sync of a policy is not a source change: it is not tracked, like
+// propagationLatencies leaves it out of the latency histogram.
+func trackPropagation(
+	prev v1alpha1.IdentitySyncPolicyStatus,
+	currentHash string,
+	synced bool,
+	start, now time.Time,
+) propagationSummary {
+	pending := prev.PendingPropagation
+	if prev.ObservedSourceSecretHash == "" || currentHash == prev.ObservedSourceSecretHash {
+		// Nothing to propagate, or the source was changed back before the
+		// change reached every target.
+		return propagationSummary{}
+	}
+	if !synced {
+		if pending == nil {
+			return propagationSummary{pending: &v1alpha1.Propagation{SourceHash: currentHash, StartTime: metav1.NewTime(start)}}
+		}
+		next := pending.DeepCopy()
+		next.SourceHash = currentHash
+		return propagationSummary{pending: next}
+	}
+
+	startTime := metav1.NewTime(start)
+	if pending != nil {
+		startTime = pending.StartTime
+	}
+	completion := metav1.NewTime(now)
+	return propagationSummary{last: &v1alpha1.Propagation{
+		SourceHash:     currentHash,
+		StartTime:      startTime,
+		CompletionTime: &completion,
+		Duration:       &metav1.Duration{Duration: max(now.Sub(startTime.Time), 0)},
+	}}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestTrackPropagation(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	start, now := t0.Add(10*time.Minute), t0.Add(11*time.Minute)
+	pending := &v1alpha1.Propagation{SourceHash: "h2", StartTime: metav1.NewTime(t0)}
+
+	tests := []struct {
+		name        string
+		prev        v1alpha1.IdentitySyncPolicyStatus
+		hash        string
+		synced      bool
+		wantPending *v1alpha1.Propagation
+		wantLast    time.Duration
+	}{
+		{
+			name: "in_sync",
+			prev: v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1"},
+			hash: "h1",
+		},
+		{
+			name:        "first_observed_partial",
+			prev:        v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1"},
+			hash:        "h2",
+			wantPending: &v1alpha1.Propagation{SourceHash: "h2", StartTime: metav1.NewTime(start)},
+		},
+		{
+			name:        "still_partial_keeps_start",
+			prev:        v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1", PendingPropagation: pending},
+			hash:        "h2",
+			wantPending: pending,
+		},
+		{
+			name:        "changed_again_keeps_start",
+			prev:        v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1", PendingPropagation: pending},
+			hash:        "h3",
+			wantPending: &v1alpha1.Propagation{SourceHash: "h3", StartTime: metav1.NewTime(t0)},
+		},
+		{
+			name:     "completed_across_reconciles",
+			prev:     v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1", PendingPropagation: pending},
+			hash:     "h2",
+			synced:   true,
+			wantLast: 11 * time.Minute,
+		},
+		{
+			name:     "completed_in_one_reconcile",
+			prev:     v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h1"},
+			hash:     "h2",
+			synced:   true,
+			wantLast: time.Minute,
+		},
+		{
+			name:   "first_sync",
+			hash:   "h1",
+			synced: true,
+		},
+		{
+			name: "first_sync_partial",
+			hash: "h1",
+		},
+		{
+			name: "changed_back",
+			prev: v1alpha1.IdentitySyncPolicyStatus{ObservedSourceSecretHash: "h2", PendingPropagation: pending},
+			hash: "h2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trackPropagation(tt.prev, tt.hash, tt.synced, start, now)
+			if (got.pending == nil) != (tt.wantPending == nil) ||
+				got.pending != nil && (got.pending.SourceHash != tt.wantPending.SourceHash ||
+					!got.pending.StartTime.Equal(&tt.wantPending.StartTime)) {
+				t.Fatalf("pending = %+v, want %+v", got.pending, tt.wantPending)
+			}
+			if tt.wantLast == 0 {
+				if got.last != nil {
+					t.Fatalf("last = %+v, want none", got.last)
+				}
+				return
+			}
+			if got.last == nil || got.last.SourceHash != tt.hash || got.last.Duration.Duration != tt.wantLast {
+				t.Fatalf("last = %+v, want %s for %s", got.last, tt.wantLast, tt.hash)
+			}
+		})
+	}
+}
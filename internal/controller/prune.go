@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+// pruneStaleTargets deletes Secrets and ServiceAccounts controlled by the policy
+// that are no longer desired, either because their namespace left the target set
+// or because the target name changed in the spec. A dry run only records them.
+// A source Secret adopted as a target is released instead of deleted.
+func pruneStaleTargets(
+	ctx context.Context,
+	k8sScheme *runtime.Scheme,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+	targetNamespaces []string,
+	dryRun bool,
+	observation *Observation,
+) {
+	var opts []client.DeleteOption
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	desired := make(map[string]struct{}, len(targetNamespaces))
+	for _, namespace := range targetNamespaces {
+		desired[namespace] = struct{}{}
+	}
+
+	targets, err := listManagedTargets(ctx, k8sClient, identity)
+	if err != nil {
+		kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+		observation.ObservePruneFailure("", kind, reason, err)
+		return
+	}
+
+	for _, target := range targets {
+		if !isStaleTarget(identity, target, desired) {
+			continue
+		}
+		if isSourceSecret(identity, target) {
+			if dryRun {
+				continue
+			}
+			if err := orphanTarget(ctx, k8sScheme, k8sClient, identity, target); err != nil {
+				kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+				observation.ObservePruneFailure(target.GetNamespace(), kind, reason, err)
+			}
+			continue
+		}
+		if err := k8sClient.Delete(ctx, target, opts...); err != nil && !apierrors.IsNotFound(err) {
+			kind, reason := errclass.ClassifyError(err, errclass.NotFoundAsTransient)
+			observation.ObservePruneFailure(target.GetNamespace(), kind, reason, err)
+			continue
+		}
+		if dryRun {
+			observation.ObservePlanned(plannedChange{
+				namespace: target.GetNamespace(),
+				action:    changeDelete,
+				object:    targetKind(target) + "/" + target.GetName(),
+			})
+			continue
+		}
+		observation.ObservePruned()
+	}
+}
+
+// listManagedTargets returns the target objects labelled with the policy UID
+// and controlled by the policy.
+func listManagedTargets(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+) ([]client.Object, error) {
+	selector := client.MatchingLabels{LabelPolicyUID: string(identity.UID)}
+
+	var secrets corev1.SecretList
+	if err := k8sClient.List(ctx, &secrets, selector); err != nil {
+		return nil, err
+	}
+	var serviceAccounts corev1.ServiceAccountList
+	if err := k8sClient.List(ctx, &serviceAccounts, selector); err != nil {
+		return nil, err
+	}
+
+	targets := make([]client.Object, 0, len(secrets.Items)+len(serviceAccounts.Items))
+	for i := range secrets.Items {
+		if metav1.IsControlledBy(&secrets.Items[i], identity) {
+			targets = append(targets, &secrets.Items[i])
+		}
+	}
+	for i := range serviceAccounts.Items {
+		if metav1.IsControlledBy(&serviceAccounts.Items[i], identity) {
+			targets = append(targets, &serviceAccounts.Items[i])
+		}
+	}
+	return targets, nil
+}
+
+func isStaleTarget(identity *v1alpha1.IdentitySyncPolicy, target client.Object, desired map[string]struct{}) bool {
+	if _, ok := desired[target.GetNamespace()]; !ok {
+		return true
+	}
+	switch target.(type) {
+	case *corev1.Secret:
+		for _, secret := range identity.Spec.AllSecrets() {
+			if target.GetName() == secret.Name {
+				return false
+			}
+		}
+		return true
+	case *corev1.ServiceAccount:
+		return target.GetName() != identity.Spec.ServiceAccount.Name
+	default:
+		return false
+	}
+}
+
+func targetKind(target client.Object) string {
+	switch target.(type) {
+	case *corev1.Secret:
+		return "Secret"
+	case *corev1.ServiceAccount:
+		return "ServiceAccount"
+	default:
+		return fmt.Sprintf("%T", target)
+	}
+}
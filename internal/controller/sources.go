@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+// source pairs a spec secret entry with the source Secret it references.
+type source struct {
+	spec   v1alpha1.Secret
+	secret *corev1.Secret
+	hash   string
+}
+
+// loadSources reads the source Secret of every spec secret entry.
+//
+// --- Partial availability ---
+// A missing source is returned by target name instead of failing the reconcile,
+// so the available sources are still fanned out. Any other read error aborts.
+func loadSources(
+	ctx context.Context,
+	k8sClient client.Reader,
+	identity *v1alpha1.IdentitySyncPolicy,
+) ([]source, []string, error) {
+	specs := identity.Spec.AllSecrets()
+	sources := make([]source, 0, len(specs))
+	var missing []string
+	for _, spec := range specs {
+		key := types.NamespacedName{Name: spec.SourceRef.Name, Namespace: spec.SourceRef.Namespace}
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, spec.Name)
+				continue
+			}
+			return nil, nil, err
+		}
+		sources = append(sources, source{spec: spec, secret: secret, hash: sourceFingerprint(spec, secret)})
+	}
+	return sources, missing, nil
+}
+
+// sourcesFingerprint combines the per-source fingerprints into a single hash
+// that does not depend on the order of spec.secrets.
+func sourcesFingerprint(sources []source) string {
+	entries := make([]string, 0, len(sources))
+	for _, src := range sources {
+		entries = append(entries, src.spec.Name+"="+src.hash)
+	}
+	sort.Strings(entries)
+
+	h := sha256.New()
+	for _, entry := range entries {
+		h.Write([]byte(entry))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// buildSecretStatuses reports availability of every source. ObservedHash only
+// moves when the fan-out succeeded; otherwise the previous value is kept.
+func buildSecretStatuses(
+	prev []v1alpha1.SecretStatus,
+	sources []source,
+	missing []string,
+	succeeded bool,
+) []v1alpha1.SecretStatus {
+	prevByName := make(map[string]v1alpha1.SecretStatus, len(prev))
+	for _, st := range prev {
+		prevByName[st.Name] = st
+	}
+
+	statuses := make([]v1alpha1.SecretStatus, 0, len(sources)+len(missing))
+	for _, src := range sources {
+		next := v1alpha1.SecretStatus{Name: src.spec.Name, Available: true}
+		if succeeded {
+			next.ObservedHash = src.hash
+		} else {
+			next.ObservedHash = prevByName[src.spec.Name].ObservedHash
+		}
+		statuses = append(statuses, next)
+	}
+	for _, name := range missing {
+		statuses = append(statuses, v1alpha1.SecretStatus{
+			Name:         name,
+			ObservedHash: prevByName[name].ObservedHash,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func missingSourcesMessage(missing []string) string {
+	return "Reference secret not found: " + strings.Join(missing, ", ")
+}
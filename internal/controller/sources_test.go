@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func src(name, hash string) source {
+	return source{spec: v1alpha1.Secret{Name: name}, hash: hash}
+}
+
+func TestSourcesFingerprint(t *testing.T) {
+	ab := sourcesFingerprint([]source{src("a", "h1"), src("b", "h2")})
+	ba := sourcesFingerprint([]source{src("b", "h2"), src("a", "h1")})
+	if ab != ba {
+		t.Fatalf("fingerprint depends on source order: %s != %s", ab, ba)
+	}
+	if swapped := sourcesFingerprint([]source{src("a", "h2"), src("b", "h1")}); swapped == ab {
+		t.Fatalf("fingerprint does not bind hashes to source names")
+	}
+}
+
+func TestBuildSecretStatuses(t *testing.T) {
+	prev := []v1alpha1.SecretStatus{
+		{Name: "api", Available: true, ObservedHash: "old-api"},
+		{Name: "tls", Available: true, ObservedHash: "old-tls"},
+	}
+	sources := []source{src("tls", "new-tls"), src("api", "new-api")}
+
+	got := buildSecretStatuses(prev, sources, []string{"pull"}, false)
+	want := []v1alpha1.SecretStatus{
+		{Name: "api", Available: true, ObservedHash: "old-api"},
+		{Name: "pull"},
+		{Name: "tls", Available: true, ObservedHash: "old-tls"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("buildSecretStatuses() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("buildSecretStatuses()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	got = buildSecretStatuses(prev, sources, nil, true)
+	if got[0].ObservedHash != "new-api" || got[1].ObservedHash != "new-tls" {
+		t.Fatalf("successful fan-out should move ObservedHash, got %+v", got)
+	}
+}
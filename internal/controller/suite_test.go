@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -41,6 +42,9 @@ func TestControllers(t *testing.T) {
 	RunSpecs(t, "Controller Suite")
 }
 
+// pauseConfigMapKey is the pause switch of the controller under test.
+var pauseConfigMapKey = types.NamespacedName{Namespace: "default", Name: "identity-operator-pause"}
+
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
@@ -83,6 +87,9 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		logging.NewLimiter(10),
 		noopmetrics.Recorder{},
+		noopmetrics.Tracer{},
+		k8sManager.GetEventRecorderFor("identity-sync-policy"),
+		Options{PauseConfigMap: pauseConfigMapKey},
 	)
 
 	Expect(controller.SetupWithManager(k8sManager)).To(Succeed())
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+const maxTargetMessageLen = 256
+
+// maxTargetStatusItems is the MaxItems of status.targets. Failed namespaces
+// beyond it cannot be listed at all.
+const maxTargetStatusItems = 1000
+
+// targetsSummary is the per-target part of status derived from one fan-out.
+type targetsSummary struct {
+	targets []v1alpha1.TargetStatus
+	// sourceHash is the combined source hash the fan-out wrote.
+	sourceHash string
+	desired    int32
+	synced     int32
+	failed     int32
+}
+
+// buildTargetsSummary derives per-target status from the observation.
+//
+// --- Minimal churn ---
+// LastSyncTime only moves when a namespace receives a new hash (or recovers),
+// and a failed or skipped namespace keeps the hash it last synced, so repeated
+// reconciles with the same results produce an identical status.
+//
+// --- Bounded size ---
+// At most maxTargets namespaces are listed. Synced namespaces are trimmed
+// first and skipped ones next, by namespace; failed namespaces are always
+// listed, since their entries carry the retry schedule.
+func buildTargetsSummary(
+	prev []v1alpha1.TargetStatus,
+	obs *Observation,
+	currentHash string,
+	now metav1.Time,
+	maxTargets int,
+) *targetsSummary {
+	prevByNamespace := make(map[string]v1alpha1.TargetStatus, len(prev))
+	for _, target := range prev {
+		prevByNamespace[target.Namespace] = target
+	}
+
+	summary := &targetsSummary{
+		targets:    make([]v1alpha1.TargetStatus, 0, len(obs.Results)),
+		sourceHash: currentHash,
+		desired:    int32(obs.Total),
+	}
+	for _, res := range obs.Results {
+		previous, found := prevByNamespace[res.Namespace]
+		next := v1alpha1.TargetStatus{Namespace: res.Namespace}
+
+		switch {
+		case res.Skipped != "":
+			next.State = res.Skipped
+			next.Reason = string(res.Reason)
+			next.Message = truncate(res.Message, maxTargetMessageLen)
+			if found {
+				next.SyncedHash = previous.SyncedHash
+				next.LastSyncTime = previous.LastSyncTime
+			}
+		case res.Failed:
+			summary.failed++
+			next.State = v1alpha1.TargetStateFailed
+			next.Reason = string(res.Reason)
+			next.Message = truncate(res.Message, maxTargetMessageLen)
+			next.ConsecutiveFailures = res.Failures
+			if !res.RetryAt.IsZero() {
+				retryTime := metav1.NewTime(res.RetryAt)
+				next.NextRetryTime = &retryTime
+			}
+			if found {
+				next.SyncedHash = previous.SyncedHash
+				next.LastSyncTime = previous.LastSyncTime
+			}
+		default:
+			summary.synced++
+			next.State = v1alpha1.TargetStateSynced
+			next.SyncedHash = currentHash
+			if found && previous.State == v1alpha1.TargetStateSynced && previous.SyncedHash == currentHash {
+				next.LastSyncTime = previous.LastSyncTime
+			} else {
+				syncTime := now
+				next.LastSyncTime = &syncTime
+			}
+		}
+		summary.targets = append(summary.targets, next)
+	}
+
+	if limit := min(max(maxTargets, int(summary.failed)), maxTargetStatusItems); len(summary.targets) > limit {
+		sort.Slice(summary.targets, func(i, j int) bool {
+			a, b := summary.targets[i], summary.targets[j]
+			if ra, rb := trimRank(a.State), trimRank(b.State); ra != rb {
+				return ra < rb
+			}
+			return a.Namespace < b.Namespace
+		})
+		summary.targets = summary.targets[:limit]
+	}
+	sort.Slice(summary.targets, func(i, j int) bool {
+		return summary.targets[i].Namespace < summary.targets[j].Namespace
+	})
+	return summary
+}
+
+// trimRank orders target states by how long their entries are kept when
+// status.targets is trimmed; lower is kept longer.
+func trimRank(state v1alpha1.TargetState) int {
+	switch state {
+	case v1alpha1.TargetStateFailed:
+		return 0
+	case v1alpha1.TargetStateSynced:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (s *targetsSummary) applyTo(st *v1alpha1.IdentitySyncPolicyStatus) {
+	st.Targets = s.targets
+	st.TargetsSourceHash = s.sourceHash
+	st.Desired = s.desired
+	st.Synced = s.synced
+	st.Failed = s.failed
+}
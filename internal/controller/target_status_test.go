@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+func TestBuildTargetsSummary(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2026, time.January, 2, 11, 0, 0, 0, time.UTC))
+
+	prev := []v1alpha1.TargetStatus{
+		{Namespace: "app-a", State: v1alpha1.TargetStateSynced, SyncedHash: "hashA", LastSyncTime: &earlier},
+		{Namespace: "app-b", State: v1alpha1.TargetStateSynced, SyncedHash: "hashA", LastSyncTime: &earlier},
+		{Namespace: "app-c", State: v1alpha1.TargetStateSynced, SyncedHash: "old", LastSyncTime: &earlier},
+		{Namespace: "app-e", State: v1alpha1.TargetStateSynced, SyncedHash: "hashA", LastSyncTime: &earlier},
+	}
+
+	obs := NewObservation(5, 10)
+	obs.ObserveSuccess("app-d")
+	obs.ObserveSuccess("app-a")
+	obs.ObserveFailure("app-b", errclass.KindConfig, errclass.ReasonForbidden, errors.New("forbidden"))
+	obs.ObserveSuccess("app-c")
+	obs.ObserveSkipped("app-e", v1alpha1.TargetStateTerminating, errclass.ReasonNotFound, nil)
+
+	got := buildTargetsSummary(prev, obs, "hashA", now, 10)
+
+	if got.desired != 5 || got.synced != 3 || got.failed != 1 {
+		t.Fatalf("unexpected counters desired=%d synced=%d failed=%d", got.desired, got.synced, got.failed)
+	}
+	if got.sourceHash != "hashA" {
+		t.Fatalf("sourceHash = %q, want the hash the fan-out wrote", got.sourceHash)
+	}
+	if len(got.targets) != 5 {
+		t.Fatalf("expected 5 targets, got %d", len(got.targets))
+	}
+
+	byNamespace := map[string]v1alpha1.TargetStatus{}
+	for i, target := range got.targets {
+		if i > 0 && got.targets[i-1].Namespace >= target.Namespace {
+			t.Fatalf("targets not sorted by namespace: %v", got.targets)
+		}
+		byNamespace[target.Namespace] = target
+	}
+
+	if ts := byNamespace["app-a"]; !ts.LastSyncTime.Equal(&earlier) {
+		t.Fatalf("unchanged target should keep LastSyncTime, got %v", ts.LastSyncTime)
+	}
+	if ts := byNamespace["app-b"]; ts.State != v1alpha1.TargetStateFailed ||
+		ts.Reason != string(errclass.ReasonForbidden) ||
+		ts.SyncedHash != "hashA" ||
+		!ts.LastSyncTime.Equal(&earlier) {
+		t.Fatalf("failed target should keep last synced hash and time, got %+v", ts)
+	}
+	if ts := byNamespace["app-c"]; ts.SyncedHash != "hashA" || !ts.LastSyncTime.Equal(&now) {
+		t.Fatalf("re-synced target should move LastSyncTime, got %+v", ts)
+	}
+	if ts := byNamespace["app-d"]; ts.State != v1alpha1.TargetStateSynced || !ts.LastSyncTime.Equal(&now) {
+		t.Fatalf("new target should be synced now, got %+v", ts)
+	}
+	if ts := byNamespace["app-e"]; ts.State != v1alpha1.TargetStateTerminating ||
+		ts.SyncedHash != "hashA" ||
+		!ts.LastSyncTime.Equal(&earlier) {
+		t.Fatalf("skipped target should keep last synced hash and time, got %+v", ts)
+	}
+}
+
+func TestBuildTargetsSummaryTrimsTargets(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, time.January, 2, 11, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		maxTargets int
+		want       []string
+	}{
+		{name: "synced_trimmed_first", maxTargets: 4, want: []string{"app-a", "app-c", "app-d", "app-e"}},
+		{name: "skipped_trimmed_next", maxTargets: 2, want: []string{"app-c", "app-d"}},
+		{name: "failed_always_listed", maxTargets: 0, want: []string{"app-c", "app-d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obs := NewObservation(5, 10)
+			obs.ObserveSkipped("app-a", v1alpha1.TargetStateTerminating, errclass.ReasonNotFound, nil)
+			obs.ObserveSuccess("app-b")
+			obs.ObserveFailure("app-d", errclass.KindTransient, errclass.ReasonTimeout, errors.New("timeout"))
+			obs.ObserveSkipped("app-e", v1alpha1.TargetStateSkipped, errclass.ReasonTargetConflict, nil)
+			obs.ObserveFailure("app-c", errclass.KindConfig, errclass.ReasonForbidden, errors.New("forbidden"))
+
+			got := buildTargetsSummary(nil, obs, "hashA", now, tt.maxTargets)
+
+			var namespaces []string
+			for _, target := range got.targets {
+				namespaces = append(namespaces, target.Namespace)
+			}
+			if !slices.Equal(namespaces, tt.want) {
+				t.Fatalf("targets = %v, want %v", namespaces, tt.want)
+			}
+			if got.synced != 1 || got.failed != 2 {
+				t.Fatalf("synced = %d, failed = %d, want every namespace counted", got.synced, got.failed)
+			}
+		})
+	}
+}
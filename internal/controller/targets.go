@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+// resolveTargetNamespaces returns the sorted union of spec.targetNamespaces
+// and the namespaces currently matching spec.namespaceSelector.
+//
+// --- Source namespaces ---
+// Namespaces holding a source Secret are never targets, or a selector matching
+// one would let the policy adopt, overwrite and later delete its own source.
+// They are dropped from the targets and returned, sorted, as sourceNamespaces.
+func resolveTargetNamespaces(
+	ctx context.Context,
+	k8sClient client.Client,
+	identity *v1alpha1.IdentitySyncPolicy,
+) (targets, sourceNamespaces []string, err error) {
+	seen := make(map[string]struct{}, len(identity.Spec.TargetNamespaces))
+	for _, namespace := range identity.Spec.TargetNamespaces {
+		seen[namespace] = struct{}{}
+	}
+
+	if identity.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(identity.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, nil, errclass.NewError(errclass.KindConfig, errclass.ReasonInvalid, err)
+		}
+		var list corev1.NamespaceList
+		if err := k8sClient.List(ctx, &list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, nil, err
+		}
+		for i := range list.Items {
+			seen[list.Items[i].Name] = struct{}{}
+		}
+	}
+
+	for _, secret := range identity.Spec.AllSecrets() {
+		if _, ok := seen[secret.SourceRef.Namespace]; ok {
+			delete(seen, secret.SourceRef.Namespace)
+			sourceNamespaces = append(sourceNamespaces, secret.SourceRef.Namespace)
+		}
+	}
+	sort.Strings(sourceNamespaces)
+
+	targets = make([]string, 0, len(seen))
+	for namespace := range seen {
+		targets = append(targets, namespace)
+	}
+	sort.Strings(targets)
+	return targets, sourceNamespaces, nil
+}
+
+// isSourceSecret reports whether obj is one of the policy's source Secrets.
+func isSourceSecret(identity *v1alpha1.IdentitySyncPolicy, obj client.Object) bool {
+	if _, ok := obj.(*corev1.Secret); !ok {
+		return false
+	}
+	for _, secret := range identity.Spec.AllSecrets() {
+		if secret.SourceRef.Namespace == obj.GetNamespace() && secret.SourceRef.Name == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// targetsHash a stable hash of the resolved target namespaces.
+// The input is expected to be sorted, as returned by resolveTargetNamespaces.
+func targetsHash(namespaces []string) string {
+	h := sha256.New()
+	for _, namespace := range namespaces {
+		h.Write([]byte(namespace))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
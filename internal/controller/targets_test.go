@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	sch := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+	return sch
+}
+
+func newSourcePolicy() *v1alpha1.IdentitySyncPolicy {
+	return &v1alpha1.IdentitySyncPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: types.UID("policy-uid")},
+		Spec: v1alpha1.IdentitySyncPolicySpec{
+			TargetNamespaces: []string{"app-a"},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "payments"},
+			},
+			Secret: v1alpha1.Secret{
+				Name:      "token",
+				SourceRef: v1alpha1.NamespacedNameRef{Namespace: "vault", Name: "token"},
+			},
+		},
+	}
+}
+
+func TestResolveTargetNamespacesDropsSourceNamespaces(t *testing.T) {
+	labelled := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": "payments"}}}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(labelled("app-b"), labelled("vault")).
+		Build()
+
+	targets, sourceNamespaces, err := resolveTargetNamespaces(context.Background(), k8sClient, newSourcePolicy())
+	if err != nil {
+		t.Fatalf("resolveTargetNamespaces() error = %v", err)
+	}
+	if !slices.Equal(targets, []string{"app-a", "app-b"}) {
+		t.Fatalf("targets = %v, want [app-a app-b]", targets)
+	}
+	if !slices.Equal(sourceNamespaces, []string{"vault"}) {
+		t.Fatalf("sourceNamespaces = %v, want [vault]", sourceNamespaces)
+	}
+}
+
+func TestPruneStaleTargetsReleasesAdoptedSource(t *testing.T) {
+	sch := newTestScheme(t)
+	identity := newSourcePolicy()
+	source := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "vault",
+		Name:      "token",
+		Labels:    managedMetadataLabels(identity),
+	}}
+	if err := controllerutil.SetControllerReference(identity, source, sch); err != nil {
+		t.Fatal(err)
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(source).Build()
+
+	obs := NewObservation(1, 10)
+	pruneStaleTargets(context.Background(), sch, k8sClient, identity, []string{"app-a"}, false, obs)
+
+	got := &corev1.Secret{}
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(source), got); err != nil {
+		t.Fatalf("source Secret was deleted: %v", err)
+	}
+	if metav1.IsControlledBy(got, identity) || got.Labels[LabelPolicyUID] != "" {
+		t.Fatalf("source Secret still managed: owners %v, labels %v", got.OwnerReferences, got.Labels)
+	}
+	if obs.Pruned != 0 || obs.PruneFailed != 0 {
+		t.Fatalf("pruned = %d, failed = %d, want neither", obs.Pruned, obs.PruneFailed)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"text/template"
+
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+)
+
+// maxRenderedSize bounds a single rendered value to the Secret size limit.
+const maxRenderedSize = 1 << 20
+
+var errRenderedTooLarge = errors.New("rendered value exceeds 1MiB")
+
+// templateValues is the data spec.secret.template is rendered against.
+type templateValues struct {
+	Data      map[string]string
+	Namespace string
+}
+
+// renderTemplates renders every template for one target namespace. Templates
+// are rendered in key order so the reported error is deterministic. Parse and
+// render failures are config errors: retrying cannot fix them.
+func renderTemplates(templates map[string]string, source map[string][]byte, namespace string) (map[string][]byte, error) {
+	values := templateValues{
+		Data:      make(map[string]string, len(source)),
+		Namespace: namespace,
+	}
+	for key, value := range source {
+		values.Data[key] = string(value)
+	}
+
+	rendered := make(map[string][]byte, len(templates))
+	for _, key := range slices.Sorted(maps.Keys(templates)) {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(templates[key])
+		if err != nil {
+			return nil, errclass.NewError(errclass.KindConfig, errclass.ReasonTemplate,
+				fmt.Errorf("parsing template %q: %w", key, err))
+		}
+		out := &limitedBuffer{limit: maxRenderedSize}
+		if err := tmpl.Execute(out, values); err != nil {
+			return nil, errclass.NewError(errclass.KindConfig, errclass.ReasonTemplate,
+				fmt.Errorf("rendering template %q: %w", key, err))
+		}
+		rendered[key] = out.Bytes()
+	}
+	return rendered, nil
+}
+
+// limitedBuffer fails writes past limit so a runaway template cannot exhaust memory.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errRenderedTooLarge
+	}
+	return b.Buffer.Write(p)
+}
@@ -7,9 +7,13 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"maps"
+	"slices"
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +25,15 @@ import (
 	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
 )
 
-const sourceSecretIndexKey = ".spec.secret.sourceRef"
+const (
+	sourceSecretIndexKey      = ".spec.secret.sourceRef"
+	targetNamespaceIndexKey   = ".spec.targetNamespaces"
+	namespaceSelectorIndexKey = ".spec.namespaceSelector"
+)
+
+// hasSelector is the namespaceSelectorIndexKey value of policies that set
+// spec.namespaceSelector.
+const hasSelector = "true"
 
 func mapRequestToIdentity(ctx context.Context, k8sClient client.Client, obj client.Object) []reconcile.Request {
 	secret, ok := obj.(*corev1.Secret)
@@ -60,6 +72,140 @@ func mapRequestToIdentity(ctx context.Context, k8sClient client.Client, obj clie
 	return reqs
 }
 
+func mapNamespaceToIdentity(ctx context.Context, k8sClient client.Client, obj client.Object) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+	logger := logf.FromContext(ctx).
+		WithValues(
+			"source", "Namespace",
+			"namespace", namespace.Name,
+			"handler", "mapNamespaceToIdentity",
+		)
+
+	var listed v1alpha1.IdentitySyncPolicyList
+	if err := k8sClient.List(ctx, &listed, client.MatchingFields{
+		targetNamespaceIndexKey: namespace.Name,
+	}); err != nil {
+		logger.Error(err, "Failed to list identity sync policy")
+		return nil
+	}
+	// Selectors can only be matched one by one, so only the policies that set
+	// one are listed.
+	var list v1alpha1.IdentitySyncPolicyList
+	if err := k8sClient.List(ctx, &list, client.MatchingFields{
+		namespaceSelectorIndexKey: hasSelector,
+	}); err != nil {
+		logger.Error(err, "Failed to list identity sync policy")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(listed.Items))
+	seen := make(map[types.NamespacedName]struct{}, len(listed.Items))
+	enqueue := func(cr *v1alpha1.IdentitySyncPolicy) {
+		key := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		reqs = append(reqs, reconcile.Request{NamespacedName: key})
+	}
+	for i := range listed.Items {
+		enqueue(&listed.Items[i])
+	}
+
+	namespaceLabels := labels.Set(namespace.Labels)
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if cr.Spec.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(cr.Spec.NamespaceSelector)
+		if err != nil {
+			logger.V(1).Info("skipping identity with invalid namespace selector", "policy", cr.Name, "error", err.Error())
+			continue
+		}
+		if !selector.Matches(namespaceLabels) {
+			continue
+		}
+		enqueue(cr)
+	}
+	logger.V(1).Info("mapped namespace to identities", "count", len(reqs))
+
+	return reqs
+}
+
+// namespaceChanged passes namespace events that can change the outcome of a
+// namespace selector or whether the namespace can be written into. Relabelling
+// is mapped for both the old and the new object, so policies losing a namespace
+// are enqueued as well. Creation, the start of deletion and deletion also reach
+// policies listing the namespace in spec.targetNamespaces.
+func namespaceChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			if e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				return true
+			}
+			return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// managedTargetChanged passes events for target objects carrying the operator's
+// management labels when they were deleted, or when their data or labels were
+// changed by someone else.
+func managedTargetChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			if !isManagedTarget(e.ObjectOld) && !isManagedTarget(e.ObjectNew) {
+				return false
+			}
+			if !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) {
+				return true
+			}
+			oldSecret, ok1 := e.ObjectOld.(*corev1.Secret)
+			newSecret, ok2 := e.ObjectNew.(*corev1.Secret)
+			if !ok1 || !ok2 {
+				return false
+			}
+			return oldSecret.Type != newSecret.Type || secretDataHash(newSecret) != secretDataHash(oldSecret)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isManagedTarget(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func isManagedTarget(obj client.Object) bool {
+	if obj == nil {
+		return false
+	}
+	objLabels := obj.GetLabels()
+	return objLabels[LabelManagedBy] == ID+"-operator" && objLabels[LabelPolicyUID] != ""
+}
+
 func sourceSecretDataChanged() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -83,40 +229,78 @@ func sourceSecretDataChanged() predicate.Predicate {
 }
 
 func setupIndexers(mgr ctrl.Manager) error {
-	return mgr.GetFieldIndexer().IndexField(
+	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.IdentitySyncPolicy{},
 		sourceSecretIndexKey,
 		indexerFunc,
+	); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.IdentitySyncPolicy{},
+		targetNamespaceIndexKey,
+		targetNamespaceIndexerFunc,
+	); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.IdentitySyncPolicy{},
+		namespaceSelectorIndexKey,
+		namespaceSelectorIndexerFunc,
 	)
 }
 
-func indexerFunc(obj client.Object) []string {
+func targetNamespaceIndexerFunc(obj client.Object) []string {
 	cr := obj.(*v1alpha1.IdentitySyncPolicy)
-	ref := cr.Spec.Secret.SourceRef
-	if ref.Name == "" {
+	return cr.Spec.TargetNamespaces
+}
+
+func namespaceSelectorIndexerFunc(obj client.Object) []string {
+	cr := obj.(*v1alpha1.IdentitySyncPolicy)
+	if cr.Spec.NamespaceSelector == nil {
 		return nil
 	}
-	if ref.Namespace == "" {
-		return nil
+	return []string{hasSelector}
+}
+
+func indexerFunc(obj client.Object) []string {
+	cr := obj.(*v1alpha1.IdentitySyncPolicy)
+	var keys []string
+	for _, secret := range cr.Spec.AllSecrets() {
+		ref := secret.SourceRef
+		if ref.Name == "" || ref.Namespace == "" {
+			continue
+		}
+		key := ref.Namespace + "/" + ref.Name
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
 	}
-	return []string{ref.Namespace + "/" + ref.Name}
+	return keys
 }
 
 // secretDataHash a stable hash of Secret.Data.
-// The key order is sorted to keep it deterministic.
 func secretDataHash(s *corev1.Secret) string {
+	return dataHash(s.Data)
+}
+
+// dataHash a stable hash of Secret data.
+// The key order is sorted to keep it deterministic.
+func dataHash(data map[string][]byte) string {
 	h := sha256.New()
 
-	keys := make([]string, 0, len(s.Data))
-	for k := range s.Data {
+	keys := make([]string, 0, len(data))
+	for k := range data {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
 	for _, k := range keys {
 		h.Write([]byte(k))
-		h.Write(s.Data[k])
+		h.Write(data[k])
 	}
 
 	return hex.EncodeToString(h.Sum(nil))
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+)
+
+func TestMapNamespaceToIdentity(t *testing.T) {
+	policyWith := func(name string, targets []string, selector map[string]string) *v1alpha1.IdentitySyncPolicy {
+		identity := &v1alpha1.IdentitySyncPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		identity.Spec.TargetNamespaces = targets
+		if selector != nil {
+			identity.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: selector}
+		}
+		return identity
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(
+			policyWith("listed", []string{"app-a"}, nil),
+			policyWith("selected", nil, map[string]string{"team": "payments"}),
+			policyWith("listed-and-selected", []string{"app-a"}, map[string]string{"team": "payments"}),
+			policyWith("other-selector", nil, map[string]string{"team": "search"}),
+			policyWith("other-namespace", []string{"app-b"}, nil),
+		).
+		WithIndex(&v1alpha1.IdentitySyncPolicy{}, targetNamespaceIndexKey, targetNamespaceIndexerFunc).
+		WithIndex(&v1alpha1.IdentitySyncPolicy{}, namespaceSelectorIndexKey, namespaceSelectorIndexerFunc).
+		Build()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "app-a",
+		Labels: map[string]string{"team": "payments"},
+	}}
+	var got []string
+	for _, req := range mapNamespaceToIdentity(context.Background(), k8sClient, namespace) {
+		got = append(got, req.Name)
+	}
+	slices.Sort(got)
+
+	want := []string{"listed", "listed-and-selected", "selected"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("mapNamespaceToIdentity() = %v, want %v", got, want)
+	}
+}
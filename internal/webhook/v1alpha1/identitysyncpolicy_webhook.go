@@ -0,0 +1,306 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var identitysyncpolicylog = logf.Log.WithName("identitysyncpolicy-resource")
+
+// SetupIdentitySyncPolicyWebhookWithManager registers the webhook for IdentitySyncPolicy in the manager.
+func SetupIdentitySyncPolicyWebhookWithManager(mgr ctrl.Manager, protected guard.Namespaces) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.IdentitySyncPolicy{}).
+		WithValidator(&IdentitySyncPolicyCustomValidator{client: mgr.GetClient(), protected: protected}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-identity-lapacek-labs-org-v1alpha1-identitysyncpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=identity.lapacek-labs.org,resources=identitysyncpolicies,verbs=create;update,versions=v1alpha1,name=videntitysyncpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IdentitySyncPolicyCustomValidator rejects policies whose mistakes would otherwise
+// only surface as Conflict/Forbidden reasons at reconcile time.
+//
+// Only spec.targetNamespaces is checked here. Namespaces matched by
+// spec.namespaceSelector are resolved at reconcile time and change without the
+// policy being updated, so they cannot be validated on admission.
+type IdentitySyncPolicyCustomValidator struct {
+	client    client.Reader
+	protected guard.Namespaces
+}
+
+var _ webhook.CustomValidator = &IdentitySyncPolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type IdentitySyncPolicy.
+func (v *IdentitySyncPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	identity, ok := obj.(*v1alpha1.IdentitySyncPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentitySyncPolicy object but got %T", obj)
+	}
+	identitysyncpolicylog.V(1).Info("Validation for IdentitySyncPolicy upon creation", "name", identity.GetName())
+
+	return nil, v.validate(ctx, identity, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type IdentitySyncPolicy.
+func (v *IdentitySyncPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	identity, ok := newObj.(*v1alpha1.IdentitySyncPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentitySyncPolicy object for the newObj but got %T", newObj)
+	}
+	identitysyncpolicylog.V(1).Info("Validation for IdentitySyncPolicy upon update", "name", identity.GetName())
+
+	// Finalizer removal on a deleting policy must never be blocked by spec validation.
+	if !identity.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	// --- Existing violations ---
+	// A policy admitted before a rule was added may break it. Updates that
+	// leave the spec alone, such as the controller adding its finalizer, and
+	// updates that fix nothing but add no violation, such as setting
+	// spec.suspend during an incident, must still go through.
+	old, ok := oldObj.(*v1alpha1.IdentitySyncPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentitySyncPolicy object for the oldObj but got %T", oldObj)
+	}
+	if equality.Semantic.DeepEqual(old.Spec, identity.Spec) {
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, identity, old)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type IdentitySyncPolicy.
+func (v *IdentitySyncPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate rejects the violations of identity. When old is set, only the
+// violations old did not already have are rejected.
+func (v *IdentitySyncPolicyCustomValidator) validate(
+	ctx context.Context,
+	identity, old *v1alpha1.IdentitySyncPolicy,
+) error {
+	var others v1alpha1.IdentitySyncPolicyList
+	if err := v.client.List(ctx, &others); err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("listing identity sync policies: %w", err))
+	}
+
+	allErrs := validateSpec(identity, others.Items, v.protected)
+	if old != nil {
+		allErrs = addedErrors(validateSpec(old, others.Items, v.protected), allErrs)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		v1alpha1.GroupVersion.WithKind("IdentitySyncPolicy").GroupKind(),
+		identity.Name,
+		allErrs,
+	)
+}
+
+// addedErrors returns the errors in next that are not in prev.
+func addedErrors(prev, next field.ErrorList) field.ErrorList {
+	existing := make(map[string]struct{}, len(prev))
+	for _, err := range prev {
+		existing[err.Error()] = struct{}{}
+	}
+	var added field.ErrorList
+	for _, err := range next {
+		if _, ok := existing[err.Error()]; !ok {
+			added = append(added, err)
+		}
+	}
+	return added
+}
+
+func validateSpec(
+	identity *v1alpha1.IdentitySyncPolicy,
+	others []v1alpha1.IdentitySyncPolicy,
+	protected guard.Namespaces,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	spec := identity.Spec
+	targetsPath := field.NewPath("spec", "targetNamespaces")
+	secrets := secretFields(spec)
+
+	sourceNamespaces := make(map[string]struct{}, len(secrets))
+	for _, secret := range secrets {
+		sourceNamespaces[secret.SourceRef.Namespace] = struct{}{}
+	}
+	for i, namespace := range spec.TargetNamespaces {
+		if _, ok := sourceNamespaces[namespace]; ok {
+			allErrs = append(allErrs, field.Invalid(targetsPath.Index(i), namespace,
+				"must not contain a source Secret namespace"))
+		}
+		if protected.Protected(namespace) {
+			allErrs = append(allErrs, field.Forbidden(targetsPath.Index(i),
+				fmt.Sprintf("namespace %q is protected", namespace)))
+		}
+	}
+	selectorPath := field.NewPath("spec", "namespaceSelector")
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.NamespaceSelector,
+		metav1validation.LabelSelectorValidationOptions{}, selectorPath)...)
+	allErrs = append(allErrs, validateSelectorSources(spec.NamespaceSelector, sourceNamespaces, selectorPath)...)
+
+	createPath := field.NewPath("spec", "createNamespaces")
+	allErrs = append(allErrs, metav1validation.ValidateLabels(spec.CreateNamespaces.Labels, createPath.Child("labels"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(spec.CreateNamespaces.Annotations, createPath.Child("annotations"))...)
+
+	allErrs = append(allErrs, validateRetryPolicy(spec.RetryPolicy, field.NewPath("spec", "retryPolicy"))...)
+
+	for _, secret := range secrets {
+		templatePath := secret.path.Child("template")
+		for _, key := range slices.Sorted(maps.Keys(secret.Template)) {
+			if _, err := template.New(key).Parse(secret.Template[key]); err != nil {
+				allErrs = append(allErrs, field.Invalid(templatePath.Key(key), secret.Template[key], err.Error()))
+			}
+		}
+	}
+
+	for i := range others {
+		other := &others[i]
+		if other.Name == identity.Name {
+			continue
+		}
+		shared := sharedNamespaces(spec.TargetNamespaces, other.Spec.TargetNamespaces)
+		if len(shared) == 0 {
+			continue
+		}
+		for _, secret := range secrets {
+			for _, otherSecret := range other.Spec.AllSecrets() {
+				if otherSecret.Name == secret.Name {
+					allErrs = append(allErrs, field.Duplicate(secret.path.Child("name"),
+						fmt.Sprintf("%s (also written by policy %q into %v)", secret.Name, other.Name, shared)))
+				}
+			}
+		}
+		if other.Spec.ServiceAccount.Name == spec.ServiceAccount.Name {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec", "serviceAccount", "name"),
+				fmt.Sprintf("%s (also managed by policy %q in %v)", spec.ServiceAccount.Name, other.Name, shared)))
+		}
+	}
+	return allErrs
+}
+
+// validateSelectorSources rejects a namespaceSelector that names a source
+// Secret namespace by its kubernetes.io/metadata.name label.
+//
+// --- Selectors are not resolved ---
+// Admission cannot know which namespaces a selector will match, now or later,
+// so only selectors naming a namespace are checked. The controller drops
+// source namespaces from the resolved targets and reports them as skipped.
+func validateSelectorSources(
+	selector *metav1.LabelSelector,
+	sourceNamespaces map[string]struct{},
+	path *field.Path,
+) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if namespace, ok := selector.MatchLabels[corev1.LabelMetadataName]; ok {
+		if _, isSource := sourceNamespaces[namespace]; isSource {
+			allErrs = append(allErrs, field.Invalid(path.Child("matchLabels").Key(corev1.LabelMetadataName), namespace,
+				"must not select a source Secret namespace"))
+		}
+	}
+	for i, expr := range selector.MatchExpressions {
+		if expr.Key != corev1.LabelMetadataName || expr.Operator != metav1.LabelSelectorOpIn {
+			continue
+		}
+		for _, namespace := range expr.Values {
+			if _, isSource := sourceNamespaces[namespace]; isSource {
+				allErrs = append(allErrs, field.Invalid(path.Child("matchExpressions").Index(i).Child("values"), namespace,
+					"must not select a source Secret namespace"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateRetryPolicy requires positive delays and a maxBackoff no shorter
+// than the delays it caps.
+func validateRetryPolicy(rp v1alpha1.RetryPolicy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	delays := []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"transientDelay", rp.TransientDelay},
+		{"permanentDelay", rp.PermanentDelay},
+		{"maxBackoff", rp.MaxBackoff},
+	}
+	for _, delay := range delays {
+		if delay.duration != nil && delay.duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(delay.name), delay.duration.Duration.String(),
+				"must be positive"))
+		}
+	}
+	if rp.MaxBackoff == nil {
+		return allErrs
+	}
+	for _, delay := range delays[:2] {
+		if delay.duration != nil && delay.duration.Duration > rp.MaxBackoff.Duration {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxBackoff"), rp.MaxBackoff.Duration.String(),
+				fmt.Sprintf("must not be shorter than %s", delay.name)))
+		}
+	}
+	return allErrs
+}
+
+// secretField is a spec secret entry with the field path it was declared at.
+type secretField struct {
+	v1alpha1.Secret
+	path *field.Path
+}
+
+func secretFields(spec v1alpha1.IdentitySyncPolicySpec) []secretField {
+	fields := make([]secretField, 0, len(spec.Secrets)+1)
+	if spec.Secret.Name != "" {
+		fields = append(fields, secretField{Secret: spec.Secret, path: field.NewPath("spec", "secret")})
+	}
+	secretsPath := field.NewPath("spec", "secrets")
+	for i, secret := range spec.Secrets {
+		fields = append(fields, secretField{Secret: secret, path: secretsPath.Index(i)})
+	}
+	return fields
+}
+
+func sharedNamespaces(a, b []string) []string {
+	set := make(map[string]struct{}, len(b))
+	for _, namespace := range b {
+		set[namespace] = struct{}{}
+	}
+	var shared []string
+	for _, namespace := range a {
+		if _, ok := set[namespace]; ok {
+			shared = append(shared, namespace)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/lapacek-labs/identity-operator/api/v1alpha1"
+	"github.com/lapacek-labs/identity-operator/pkg/guard"
+)
+
+func policy(name, secretName, serviceAccountName string, targets ...string) v1alpha1.IdentitySyncPolicy {
+	return v1alpha1.IdentitySyncPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.IdentitySyncPolicySpec{
+			TargetNamespaces: targets,
+			ServiceAccount:   v1alpha1.ServiceAccount{Name: serviceAccountName},
+			Secret: v1alpha1.Secret{
+				Name: secretName,
+				SourceRef: v1alpha1.NamespacedNameRef{
+					Name:      "source",
+					Namespace: "platform",
+				},
+			},
+		},
+	}
+}
+
+func withTemplate(identity v1alpha1.IdentitySyncPolicy, template map[string]string) v1alpha1.IdentitySyncPolicy {
+	identity.Spec.Secret.Template = template
+	return identity
+}
+
+func withSecrets(identity v1alpha1.IdentitySyncPolicy, secrets ...v1alpha1.Secret) v1alpha1.IdentitySyncPolicy {
+	identity.Spec.Secrets = secrets
+	return identity
+}
+
+func withCreateNamespaces(identity v1alpha1.IdentitySyncPolicy, labels, annotations map[string]string) v1alpha1.IdentitySyncPolicy {
+	identity.Spec.CreateNamespaces = v1alpha1.CreateNamespaces{Enabled: true, Labels: labels, Annotations: annotations}
+	return identity
+}
+
+func withRetryPolicy(identity v1alpha1.IdentitySyncPolicy, transient, maxBackoff time.Duration) v1alpha1.IdentitySyncPolicy {
+	identity.Spec.RetryPolicy = v1alpha1.RetryPolicy{
+		TransientDelay: &metav1.Duration{Duration: transient},
+		MaxBackoff:     &metav1.Duration{Duration: maxBackoff},
+	}
+	return identity
+}
+
+func withSelector(identity v1alpha1.IdentitySyncPolicy, selector *metav1.LabelSelector) v1alpha1.IdentitySyncPolicy {
+	identity.Spec.NamespaceSelector = selector
+	return identity
+}
+
+func TestValidateSpec(t *testing.T) {
+	protected := guard.NewNamespaces(guard.DefaultProtectedNamespaces)
+
+	tests := []struct {
+		name       string
+		identity   v1alpha1.IdentitySyncPolicy
+		others     []v1alpha1.IdentitySyncPolicy
+		wantFields []string
+	}{
+		{
+			name:     "valid_policy",
+			identity: policy("a", "token", "sa", "app-1", "app-2"),
+			others:   []v1alpha1.IdentitySyncPolicy{policy("b", "token", "sa", "app-3")},
+		},
+		{
+			name:       "source_namespace_in_targets",
+			identity:   policy("a", "token", "sa", "app-1", "platform"),
+			wantFields: []string{"spec.targetNamespaces[1]"},
+		},
+		{
+			name:       "protected_namespace_in_targets",
+			identity:   policy("a", "token", "sa", "kube-system"),
+			wantFields: []string{"spec.targetNamespaces[0]"},
+		},
+		{
+			name:       "secret_name_collides_in_shared_namespace",
+			identity:   policy("a", "token", "sa-a", "app-1", "app-2"),
+			others:     []v1alpha1.IdentitySyncPolicy{policy("b", "token", "sa-b", "app-2")},
+			wantFields: []string{"spec.secret.name"},
+		},
+		{
+			name:       "service_account_collides_in_shared_namespace",
+			identity:   policy("a", "token-a", "sa", "app-1"),
+			others:     []v1alpha1.IdentitySyncPolicy{policy("b", "token-b", "sa", "app-1")},
+			wantFields: []string{"spec.serviceAccount.name"},
+		},
+		{
+			name: "valid_template",
+			identity: withTemplate(policy("a", "token", "sa", "app-1"), map[string]string{
+				"DATABASE_URL": `postgres://{{ .Data.username }}@db.{{ .Namespace }}`,
+			}),
+		},
+		{
+			name: "unparsable_template",
+			identity: withTemplate(policy("a", "token", "sa", "app-1"), map[string]string{
+				"DATABASE_URL": `postgres://{{ .Data.username `,
+			}),
+			wantFields: []string{"spec.secret.template[DATABASE_URL]"},
+		},
+		{
+			name: "secrets_source_namespace_in_targets",
+			identity: withSecrets(policy("a", "token", "sa", "app-1", "certs"), v1alpha1.Secret{
+				Name:      "tls",
+				SourceRef: v1alpha1.NamespacedNameRef{Name: "tls", Namespace: "certs"},
+			}),
+			wantFields: []string{"spec.targetNamespaces[1]"},
+		},
+		{
+			name: "secrets_entry_collides_in_shared_namespace",
+			identity: withSecrets(policy("a", "token-a", "sa-a", "app-1"), v1alpha1.Secret{
+				Name:      "tls",
+				SourceRef: v1alpha1.NamespacedNameRef{Name: "tls", Namespace: "certs"},
+			}),
+			others:     []v1alpha1.IdentitySyncPolicy{policy("b", "tls", "sa-b", "app-1")},
+			wantFields: []string{"spec.secrets[0].name"},
+		},
+		{
+			name: "valid_create_namespaces",
+			identity: withCreateNamespaces(policy("a", "token", "sa", "app-1"),
+				map[string]string{"team": "payments"},
+				map[string]string{"example.com/owner": "payments@example.com"}),
+		},
+		{
+			name: "invalid_create_namespaces_label",
+			identity: withCreateNamespaces(policy("a", "token", "sa", "app-1"),
+				map[string]string{"team": "not a label value"}, nil),
+			wantFields: []string{"spec.createNamespaces.labels"},
+		},
+		{
+			name:     "valid_retry_policy",
+			identity: withRetryPolicy(policy("a", "token", "sa", "app-1"), 10*time.Second, time.Minute),
+		},
+		{
+			name:       "non_positive_retry_delay",
+			identity:   withRetryPolicy(policy("a", "token", "sa", "app-1"), 0, time.Minute),
+			wantFields: []string{"spec.retryPolicy.transientDelay"},
+		},
+		{
+			name:       "max_backoff_shorter_than_delay",
+			identity:   withRetryPolicy(policy("a", "token", "sa", "app-1"), time.Hour, time.Minute),
+			wantFields: []string{"spec.retryPolicy.maxBackoff"},
+		},
+		{
+			name: "selector_by_label",
+			identity: withSelector(policy("a", "token", "sa"), &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "payments"},
+			}),
+		},
+		{
+			name: "selector_names_source_namespace",
+			identity: withSelector(policy("a", "token", "sa"), &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "platform"},
+			}),
+			wantFields: []string{"spec.namespaceSelector.matchLabels[kubernetes.io/metadata.name]"},
+		},
+		{
+			name: "selector_expression_names_source_namespace",
+			identity: withSelector(policy("a", "token", "sa"), &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"app-1", "platform"},
+				}},
+			}),
+			wantFields: []string{"spec.namespaceSelector.matchExpressions[0].values"},
+		},
+		{
+			name: "invalid_selector_operator",
+			identity: withSelector(policy("a", "token", "sa"), &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Nope"}},
+			}),
+			wantFields: []string{"spec.namespaceSelector.matchExpressions[0].operator"},
+		},
+		{
+			name:     "update_does_not_collide_with_itself",
+			identity: policy("a", "token", "sa", "app-1"),
+			others:   []v1alpha1.IdentitySyncPolicy{policy("a", "token", "sa", "app-1")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateSpec(&tt.identity, tt.others, protected)
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("validateSpec() returned %d errors, want %d: %v", len(errs), len(tt.wantFields), errs)
+			}
+			for i, want := range tt.wantFields {
+				if errs[i].Field != want {
+					t.Fatalf("error %d on field %q, want %q", i, errs[i].Field, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	sch := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+	// The policy was admitted before kube-system became protected.
+	old := policy("a", "token", "sa", "kube-system")
+	validator := &IdentitySyncPolicyCustomValidator{
+		client:    fake.NewClientBuilder().WithScheme(sch).Build(),
+		protected: guard.NewNamespaces(guard.DefaultProtectedNamespaces),
+	}
+
+	finalized := old.DeepCopy()
+	finalized.Finalizers = []string{"identity.lapacek-labs.org/finalizer"}
+	suspended := old.DeepCopy()
+	suspended.Spec.Suspend = true
+	widened := old.DeepCopy()
+	widened.Spec.TargetNamespaces = append(widened.Spec.TargetNamespaces, "kube-public")
+
+	tests := []struct {
+		name     string
+		identity *v1alpha1.IdentitySyncPolicy
+		wantErr  bool
+	}{
+		{name: "metadata_only", identity: finalized},
+		{name: "existing_violation_kept", identity: suspended},
+		{name: "violation_added", identity: widened, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateUpdate(context.Background(), &old, tt.identity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateUpdate() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+// Package config loads the operator configuration file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+)
+
+const (
+	APIVersion = "config.identity.lapacek-labs.org/v1alpha1"
+	Kind       = "OperatorConfig"
+)
+
+// OperatorConfig is the operator-wide tuning. Fields left out of the file keep
+// their defaults.
+type OperatorConfig struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Retry      Retry     `json:"retry"`
+	Logging    Logging   `json:"logging"`
+	Reconcile  Reconcile `json:"reconcile"`
+	Fanout     Fanout    `json:"fanout"`
+}
+
+// Retry holds the requeue delays of failed reconciles.
+type Retry struct {
+	// TransientDelay is the requeue delay when a failure may resolve on its own.
+	TransientDelay metav1.Duration `json:"transientDelay"`
+	// PermanentDelay is the requeue delay when a failure needs a fix.
+	PermanentDelay metav1.Duration `json:"permanentDelay"`
+	// MaxBackoff caps the delay of a target namespace that keeps failing.
+	MaxBackoff metav1.Duration `json:"maxBackoff"`
+	// MissingSourceDelay is how long to wait before rechecking a missing source Secret.
+	MissingSourceDelay metav1.Duration `json:"missingSourceDelay"`
+	// Jitter adds a random part of up to this percentage to every delay, so
+	// policies and namespaces failing together are not retried together.
+	// spec.retryPolicy.jitter overrides it.
+	Jitter int32 `json:"jitter"`
+}
+
+// Logging throttles failure logs and Events.
+type Logging struct {
+	// LimiterSize is the number of fingerprints the limiter remembers.
+	// Changing it requires a restart.
+	LimiterSize int `json:"limiterSize"`
+	// ChangeInterval throttles logs and Events whose content changed.
+	ChangeInterval metav1.Duration `json:"changeInterval"`
+	// ReminderIntervals is how often an unchanged failure is logged again, by
+	// reconcile reason. Reasons not listed use DefaultReminderInterval.
+	ReminderIntervals map[result.Reason]metav1.Duration `json:"reminderIntervals,omitempty"`
+	// DefaultReminderInterval applies to reasons not in ReminderIntervals.
+	DefaultReminderInterval metav1.Duration `json:"defaultReminderInterval"`
+}
+
+// Reconcile configures the controller.
+type Reconcile struct {
+	// MaxConcurrentReconciles is the number of policies reconciled at once.
+	// Changing it requires a restart.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles"`
+}
+
+// Fanout bounds a single fan-out.
+type Fanout struct {
+	// Parallelism bounds concurrent target namespace reconciles per policy.
+	// --fanout-parallelism and spec.fanoutParallelism override it.
+	Parallelism int `json:"parallelism"`
+	// MaxSamples is the number of failures kept per fan-out for logs.
+	MaxSamples int `json:"maxSamples"`
+	// MaxTargetStatuses is the number of namespaces listed in status.targets.
+	// Failed namespaces are listed beyond it. It may not exceed the CRD's limit
+	// of maxTargetStatusesLimit entries.
+	MaxTargetStatuses int `json:"maxTargetStatuses"`
+}
+
+// maxTargetStatusesLimit is the MaxItems of status.targets.
+const maxTargetStatusesLimit = 1000
+
+func Default() OperatorConfig {
+	return OperatorConfig{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Retry: Retry{
+			TransientDelay:     metav1.Duration{Duration: 2 * time.Minute},
+			PermanentDelay:     metav1.Duration{Duration: 10 * time.Minute},
+			MaxBackoff:         metav1.Duration{Duration: time.Hour},
+			MissingSourceDelay: metav1.Duration{Duration: 5 * time.Minute},
+			Jitter:             10,
+		},
+		Logging: Logging{
+			LimiterSize:    1000,
+			ChangeInterval: metav1.Duration{Duration: 30 * time.Second},
+			ReminderIntervals: map[result.Reason]metav1.Duration{
+				result.ReasonNotFound:             {Duration: 20 * time.Minute},
+				result.ReasonForbidden:            {Duration: 5 * time.Minute},
+				result.ReasonInvalidSpec:          {Duration: 5 * time.Minute},
+				result.ReasonFieldManagerConflict: {Duration: 5 * time.Minute},
+				result.ReasonTimeout:              {Duration: 2 * time.Minute},
+				result.ReasonAPIServerError:       {Duration: 2 * time.Minute},
+				result.ReasonConflict:             {Duration: 2 * time.Minute},
+			},
+			DefaultReminderInterval: metav1.Duration{Duration: 10 * time.Minute},
+		},
+		Reconcile: Reconcile{
+			MaxConcurrentReconciles: 1,
+		},
+		Fanout: Fanout{
+			Parallelism:       4,
+			MaxSamples:        50,
+			MaxTargetStatuses: 100,
+		},
+	}
+}
+
+// ReminderInterval returns how often an unchanged failure with reason r is reported.
+func (l Logging) ReminderInterval(r result.Reason) time.Duration {
+	if interval, ok := l.ReminderIntervals[r]; ok {
+		return interval.Duration
+	}
+	return l.DefaultReminderInterval.Duration
+}
+
+// Load reads and validates the configuration file at path. An empty path
+// returns the defaults.
+func Load(path string) (OperatorConfig, error) {
+	if path == "" {
+		return Default(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return OperatorConfig{}, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a configuration file over the defaults and validates it.
+// Unknown fields are rejected so a typo does not silently keep a default.
+func Parse(data []byte) (OperatorConfig, error) {
+	cfg := Default()
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return OperatorConfig{}, fmt.Errorf("decoding operator config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return OperatorConfig{}, fmt.Errorf("invalid operator config: %w", err)
+	}
+	return cfg, nil
+}
+
+func (c OperatorConfig) Validate() error {
+	var errs []error
+	if c.APIVersion != APIVersion {
+		errs = append(errs, fmt.Errorf("apiVersion must be %s, got %q", APIVersion, c.APIVersion))
+	}
+	if c.Kind != Kind {
+		errs = append(errs, fmt.Errorf("kind must be %s, got %q", Kind, c.Kind))
+	}
+	durations := []struct {
+		field    string
+		duration metav1.Duration
+	}{
+		{"retry.transientDelay", c.Retry.TransientDelay},
+		{"retry.permanentDelay", c.Retry.PermanentDelay},
+		{"retry.maxBackoff", c.Retry.MaxBackoff},
+		{"retry.missingSourceDelay", c.Retry.MissingSourceDelay},
+		{"logging.changeInterval", c.Logging.ChangeInterval},
+		{"logging.defaultReminderInterval", c.Logging.DefaultReminderInterval},
+	}
+	for _, d := range durations {
+		if d.duration.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", d.field, d.duration.Duration))
+		}
+	}
+	if c.Retry.MaxBackoff.Duration < max(c.Retry.TransientDelay.Duration, c.Retry.PermanentDelay.Duration) {
+		errs = append(errs, fmt.Errorf("retry.maxBackoff must not be shorter than the retry delays, got %s",
+			c.Retry.MaxBackoff.Duration))
+	}
+	if c.Retry.Jitter < 0 || c.Retry.Jitter > 100 {
+		errs = append(errs, fmt.Errorf("retry.jitter must be between 0 and 100, got %d", c.Retry.Jitter))
+	}
+	reasons := make([]result.Reason, 0, len(c.Logging.ReminderIntervals))
+	for reason := range c.Logging.ReminderIntervals {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+	for _, reason := range reasons {
+		if !slices.Contains(result.AllReasons(), reason) {
+			errs = append(errs, fmt.Errorf("logging.reminderIntervals: unknown reason %q", reason))
+		}
+		if interval := c.Logging.ReminderIntervals[reason]; interval.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("logging.reminderIntervals[%s] must be positive, got %s", reason, interval.Duration))
+		}
+	}
+	if c.Logging.LimiterSize <= 0 {
+		errs = append(errs, fmt.Errorf("logging.limiterSize must be positive, got %d", c.Logging.LimiterSize))
+	}
+	if c.Reconcile.MaxConcurrentReconciles <= 0 {
+		errs = append(errs, fmt.Errorf("reconcile.maxConcurrentReconciles must be positive, got %d",
+			c.Reconcile.MaxConcurrentReconciles))
+	}
+	if c.Fanout.Parallelism <= 0 {
+		errs = append(errs, fmt.Errorf("fanout.parallelism must be positive, got %d", c.Fanout.Parallelism))
+	}
+	if c.Fanout.MaxSamples < 0 {
+		errs = append(errs, fmt.Errorf("fanout.maxSamples must not be negative, got %d", c.Fanout.MaxSamples))
+	}
+	if c.Fanout.MaxTargetStatuses < 0 || c.Fanout.MaxTargetStatuses > maxTargetStatusesLimit {
+		errs = append(errs, fmt.Errorf("fanout.maxTargetStatuses must be between 0 and %d, got %d",
+			maxTargetStatusesLimit, c.Fanout.MaxTargetStatuses))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/lapacek-labs/identity-operator/pkg/result"
+)
+
+func TestParse_MergesOverDefaults(t *testing.T) {
+	cfg, err := Parse([]byte(`
+apiVersion: config.identity.lapacek-labs.org/v1alpha1
+kind: OperatorConfig
+retry:
+  transientDelay: 30s
+logging:
+  reminderIntervals:
+    Forbidden: 1h
+fanout:
+  maxSamples: 10
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := cfg.Retry.TransientDelay.Duration; got != 30*time.Second {
+		t.Fatalf("transientDelay = %s, want 30s", got)
+	}
+	if got := cfg.Retry.PermanentDelay.Duration; got != 10*time.Minute {
+		t.Fatalf("permanentDelay = %s, want the 10m default", got)
+	}
+	if got := cfg.Logging.ReminderInterval(result.ReasonForbidden); got != time.Hour {
+		t.Fatalf("Forbidden reminder = %s, want 1h", got)
+	}
+	if got := cfg.Logging.ReminderInterval(result.ReasonNotFound); got != 20*time.Minute {
+		t.Fatalf("NotFound reminder = %s, want the 20m default", got)
+	}
+	if got := cfg.Logging.ReminderInterval(result.ReasonUnknown); got != 10*time.Minute {
+		t.Fatalf("Unknown reminder = %s, want the 10m default", got)
+	}
+	if cfg.Fanout.MaxSamples != 10 || cfg.Fanout.Parallelism != 4 {
+		t.Fatalf("fanout = %+v, want maxSamples 10 and the default parallelism", cfg.Fanout)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	header := "apiVersion: config.identity.lapacek-labs.org/v1alpha1\nkind: OperatorConfig\n"
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "wrong_version", data: "apiVersion: v2\nkind: OperatorConfig\n", want: "apiVersion"},
+		{name: "unknown_field", data: header + "retry:\n  transientDelai: 1m\n", want: "transientDelai"},
+		{name: "zero_delay", data: header + "retry:\n  permanentDelay: 0s\n", want: "retry.permanentDelay"},
+		{name: "short_max_backoff", data: header + "retry:\n  maxBackoff: 5m\n", want: "retry.maxBackoff"},
+		{name: "jitter_over_100", data: header + "retry:\n  jitter: 150\n", want: "retry.jitter"},
+		{name: "unknown_reason", data: header + "logging:\n  reminderIntervals:\n    Nope: 1m\n", want: "Nope"},
+		{name: "zero_parallelism", data: header + "fanout:\n  parallelism: 0\n", want: "fanout.parallelism"},
+		{
+			name: "too_many_target_statuses",
+			data: header + "fanout:\n  maxTargetStatuses: 1001\n",
+			want: "fanout.maxTargetStatuses",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Parse() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_SetKeepsStartupFields(t *testing.T) {
+	store := NewStore(Default())
+
+	next := Default()
+	next.Retry.TransientDelay.Duration = time.Second
+	next.Logging.LimiterSize = 5
+	if !store.Set(next) {
+		t.Fatalf("Set() = false, want a restart to be required")
+	}
+
+	got := store.Get()
+	if got.Retry.TransientDelay.Duration != time.Second {
+		t.Fatalf("transientDelay = %s, want 1s", got.Retry.TransientDelay.Duration)
+	}
+	if got.Logging.LimiterSize != Default().Logging.LimiterSize {
+		t.Fatalf("limiterSize = %d, want it unchanged until restart", got.Logging.LimiterSize)
+	}
+}
+
+func TestReloader_KeepsLastValidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	header := "apiVersion: config.identity.lapacek-labs.org/v1alpha1\nkind: OperatorConfig\n"
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := NewStore(Default())
+	reloader := &Reloader{Path: path, Store: store, Log: logr.Discard()}
+
+	write(header + "retry:\n  transientDelay: 15s\n")
+	reloader.reload()
+	if got := store.Get().Retry.TransientDelay.Duration; got != 15*time.Second {
+		t.Fatalf("transientDelay = %s, want 15s", got)
+	}
+
+	write(header + "retry:\n  transientDelay: -1s\n")
+	reloader.reload()
+	if got := store.Get().Retry.TransientDelay.Duration; got != 15*time.Second {
+		t.Fatalf("transientDelay = %s, want the last valid 15s", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Store holds the current configuration. It is safe for concurrent use.
+type Store struct {
+	current atomic.Pointer[OperatorConfig]
+}
+
+func NewStore(cfg OperatorConfig) *Store {
+	s := &Store{}
+	s.current.Store(&cfg)
+	return s
+}
+
+// Get returns the current configuration. Callers must not modify it.
+func (s *Store) Get() OperatorConfig {
+	return *s.current.Load()
+}
+
+// Set replaces the configuration, except the fields that are only read at
+// startup, which keep their current values. It reports whether any of those
+// differed.
+func (s *Store) Set(cfg OperatorConfig) (restartRequired bool) {
+	current := s.Get()
+	if cfg.Logging.LimiterSize != current.Logging.LimiterSize ||
+		cfg.Reconcile.MaxConcurrentReconciles != current.Reconcile.MaxConcurrentReconciles {
+		restartRequired = true
+	}
+	cfg.Logging.LimiterSize = current.Logging.LimiterSize
+	cfg.Reconcile.MaxConcurrentReconciles = current.Reconcile.MaxConcurrentReconciles
+	s.current.Store(&cfg)
+	return restartRequired
+}
+
+// Reloader polls the configuration file and applies every valid change to the
+// Store. An invalid file is reported and the last valid configuration is kept.
+//
+// --- Polling ---
+// A mounted ConfigMap is updated by swapping a symlink, which file watches do
+// not reliably follow. Comparing the content on an interval works for both.
+type Reloader struct {
+	Path     string
+	Store    *Store
+	Interval time.Duration
+	Log      logr.Logger
+
+	last []byte
+}
+
+// Start implements manager.Runnable.
+func (r *Reloader) Start(ctx context.Context) error {
+	r.last, _ = os.ReadFile(r.Path)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.reload()
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; every replica
+// reloads its own configuration.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Reloader) reload() {
+	data, err := os.ReadFile(r.Path)
+	if err != nil {
+		r.Log.Error(err, "Reading operator config failed, keeping the current one", "path", r.Path)
+		return
+	}
+	if bytes.Equal(data, r.last) {
+		return
+	}
+	r.last = data
+	cfg, err := Parse(data)
+	if err != nil {
+		r.Log.Error(err, "Operator config rejected, keeping the current one", "path", r.Path)
+		return
+	}
+	if r.Store.Set(cfg) {
+		r.Log.Info("Operator config reloaded; limiterSize and maxConcurrentReconciles apply after a restart",
+			"path", r.Path)
+		return
+	}
+	r.Log.Info("Operator config reloaded", "path", r.Path)
+}
@@ -8,7 +8,9 @@ import (
 	"errors"
 	"net/http"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ClassifyError(err error, notFoundPolicy NotFoundPolicy) (ErrorKind, ErrorReason) {
@@ -16,6 +18,12 @@ func ClassifyError(err error, notFoundPolicy NotFoundPolicy) (ErrorKind, ErrorRe
 		return "", ""
 	}
 
+	// --- Explicitly classified errors raised by the controller itself ---
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Kind, classified.Reason
+	}
+
 	// --- Fast-path: context / transport errclass (not Kubernetes StatusError) ---
 	// context.DeadlineExceeded is typically an RPC/API timeout -> retry.
 	if errors.Is(err, context.DeadlineExceeded) {
@@ -28,12 +36,20 @@ func ClassifyError(err error, notFoundPolicy NotFoundPolicy) (ErrorKind, ErrorRe
 
 	// --- Kubernetes API typed errclass (StatusError under the hood) ---
 	switch {
+	// Server-side apply field ownership conflict: another manager owns a field we
+	// set. Retrying cannot help until that manager or the policy changes.
+	case apierrors.IsConflict(err) && apierrors.HasStatusCause(err, metav1.CauseTypeFieldManagerConflict):
+		return KindConfig, ReasonFieldManagerConflict
 	// Optimistic concurrency (resourceVersion mismatch) -> retry.
 	case apierrors.IsConflict(err):
 		return KindConflict, ReasonConflict
 	// Create race: someone else already created the object -> retry via requeue.
 	case apierrors.IsAlreadyExists(err):
 		return KindConflict, ReasonConflict
+	// Namespace deletion in progress: the write is refused as Forbidden, but it
+	// is not an RBAC problem and goes away with the namespace.
+	case apierrors.IsForbidden(err) && apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause):
+		return KindTransient, ReasonNamespaceTerminating
 	// RBAC/auth misconfiguration -> non-retriable (config issue).
 	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
 		return KindConfig, ReasonForbidden
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package errclass
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	secrets := schema.GroupResource{Resource: "secrets"}
+	applyConflict := apierrors.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "other-controller"`,
+		Field:   ".metadata.labels.team",
+	}}, "Apply failed with 1 conflict")
+	namespaceTerminating := apierrors.NewForbidden(secrets, "token", errors.New("namespace app is being terminated"))
+	namespaceTerminating.ErrStatus.Details.Causes = []metav1.StatusCause{{
+		Type:    corev1.NamespaceTerminatingCause,
+		Message: "namespace app is being terminated",
+		Field:   "metadata.namespace",
+	}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantKind   ErrorKind
+		wantReason ErrorReason
+	}{
+		{
+			name:       "field_manager_conflict",
+			err:        applyConflict,
+			wantKind:   KindConfig,
+			wantReason: ReasonFieldManagerConflict,
+		},
+		{
+			name:       "wrapped_field_manager_conflict",
+			err:        fmt.Errorf("applying: %w", applyConflict),
+			wantKind:   KindConfig,
+			wantReason: ReasonFieldManagerConflict,
+		},
+		{
+			name:       "namespace_terminating",
+			err:        namespaceTerminating,
+			wantKind:   KindTransient,
+			wantReason: ReasonNamespaceTerminating,
+		},
+		{
+			name:       "forbidden",
+			err:        apierrors.NewForbidden(secrets, "token", errors.New("rbac")),
+			wantKind:   KindConfig,
+			wantReason: ReasonForbidden,
+		},
+		{
+			name:       "optimistic_lock_conflict",
+			err:        apierrors.NewConflict(secrets, "token", errors.New("object was modified")),
+			wantKind:   KindConflict,
+			wantReason: ReasonConflict,
+		},
+		{
+			name:       "explicitly_classified",
+			err:        NewError(KindConfig, ReasonTargetConflict, errors.New("taken")),
+			wantKind:   KindConfig,
+			wantReason: ReasonTargetConflict,
+		},
+		{
+			name:       "not_found_as_transient",
+			err:        apierrors.NewNotFound(secrets, "token"),
+			wantKind:   KindTransient,
+			wantReason: ReasonNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, reason := ClassifyError(tt.err, NotFoundAsTransient)
+			if kind != tt.wantKind || reason != tt.wantReason {
+				t.Fatalf("ClassifyError() = %s/%s, want %s/%s", kind, reason, tt.wantKind, tt.wantReason)
+			}
+		})
+	}
+}
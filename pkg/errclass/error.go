@@ -28,6 +28,20 @@ const (
 	ReasonTimeout   ErrorReason = "Timeout"
 	ReasonInvalid   ErrorReason = "Invalid"
 	ReasonOther     ErrorReason = "Other"
+
+	// ReasonTargetConflict marks a target object the policy refuses to take over.
+	ReasonTargetConflict ErrorReason = "TargetConflict"
+	// ReasonFieldManagerConflict marks a server-side apply rejected because
+	// another field manager owns a field the operator sets.
+	ReasonFieldManagerConflict ErrorReason = "FieldManagerConflict"
+	// ReasonTemplate marks a spec.secret.template that failed to parse or render.
+	ReasonTemplate ErrorReason = "TemplateError"
+	// ReasonNamespaceTerminating marks a write refused because the target
+	// namespace is being deleted.
+	ReasonNamespaceTerminating ErrorReason = "NamespaceTerminating"
+	// ReasonSourceNamespace marks a namespace left out of the targets because
+	// it holds a source Secret of the policy.
+	ReasonSourceNamespace ErrorReason = "SourceNamespace"
 )
 
 func AllReasons() []ErrorReason {
@@ -38,5 +52,30 @@ func AllReasons() []ErrorReason {
 		ReasonTimeout,
 		ReasonInvalid,
 		ReasonOther,
+		ReasonTargetConflict,
+		ReasonTemplate,
+		ReasonFieldManagerConflict,
+		ReasonNamespaceTerminating,
+		ReasonSourceNamespace,
 	}
 }
+
+// Error carries an explicit classification for failures that do not originate
+// from the API server (e.g. spec content the controller cannot act on).
+type Error struct {
+	Kind   ErrorKind
+	Reason ErrorReason
+	Err    error
+}
+
+func NewError(kind ErrorKind, reason ErrorReason, err error) error {
+	return &Error{Kind: kind, Reason: reason, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
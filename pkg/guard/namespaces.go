@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package guard
+
+import (
+	"strings"
+)
+
+// DefaultProtectedNamespaces are namespaces the operator never writes into.
+var DefaultProtectedNamespaces = []string{
+	"kube-system",
+	"kube-public",
+	"kube-node-lease",
+}
+
+// Namespaces is an immutable set of protected namespace names.
+type Namespaces struct {
+	names map[string]struct{}
+}
+
+func NewNamespaces(names []string) Namespaces {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		set[name] = struct{}{}
+	}
+	return Namespaces{names: set}
+}
+
+// ParseNamespaces builds the set from a comma separated flag value.
+func ParseNamespaces(value string) Namespaces {
+	return NewNamespaces(strings.Split(value, ","))
+}
+
+func (n Namespaces) Protected(namespace string) bool {
+	_, ok := n.names[namespace]
+	return ok
+}
@@ -4,6 +4,7 @@
 package observability
 
 import (
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
 	"github.com/lapacek-labs/identity-operator/pkg/result"
 )
 
@@ -16,11 +17,37 @@ type Phase string
 
 const (
 	PhasePrecondition Phase = "precondition"
+	PhaseTargets      Phase = "targets"
 	PhaseFanout       Phase = "fanout"
+	PhaseFinalize     Phase = "finalize"
+	PhaseSuspend      Phase = "suspend"
+	PhasePause        Phase = "pause"
 )
 
 type Fanout struct {
 	Total   int
 	Failed  int
 	Success int
+	Pruned  int
+	// FailureReasons counts the failed writes and deletions by reason.
+	FailureReasons map[errclass.ErrorReason]int
+}
+
+type PolicyState string
+
+const (
+	PolicyReady    PolicyState = "ready"
+	PolicyDegraded PolicyState = "degraded"
+	PolicyUnknown  PolicyState = "unknown"
+)
+
+type Policy struct {
+	// Key is the namespace/name of the policy.
+	Key   string
+	State PolicyState
+	// OutOfSync is the number of target namespaces whose last sync failed.
+	OutOfSync int
+	// OutOfSyncNamespaces are the target namespaces whose last sync failed, as
+	// far as the policy status lists them.
+	OutOfSyncNamespaces []string
 }
@@ -17,3 +17,13 @@ func (Recorder) RecordAttempt(attempt observability.Attempt, latency time.Durati
 
 func (Recorder) RecordFanout(fanout observability.Fanout) {
 }
+
+func (Recorder) RecordPaused(paused bool) {}
+
+func (Recorder) RecordPolicy(policy observability.Policy) {}
+
+func (Recorder) ForgetPolicy(key string) {}
+
+func (Recorder) RecordPropagation(latency time.Duration) {}
+
+func (Recorder) RecordPropagationCompleted(duration time.Duration) {}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package noop
+
+import (
+	"context"
+
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+)
+
+type Tracer struct{}
+
+var _ observability.Tracer = Tracer{}
+
+func (Tracer) Start(ctx context.Context, name string, attrs ...observability.Attribute) (context.Context, observability.Span) {
+	return ctx, Span{}
+}
+
+func (Tracer) SpanFromContext(ctx context.Context) observability.Span {
+	return Span{}
+}
+
+type Span struct{}
+
+func (Span) SetAttributes(attrs ...observability.Attribute) {}
+
+func (Span) Fail(err error, attrs ...observability.Attribute) {}
+
+func (Span) End() {}
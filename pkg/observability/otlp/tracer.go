@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+)
+
+const instrumentationName = "github.com/lapacek-labs/identity-operator"
+
+// Options configure the OTLP exporter.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	// Insecure disables TLS towards the collector.
+	Insecure bool
+	// SampleRatio is the fraction of traces recorded, from 0 to 1.
+	SampleRatio float64
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+}
+
+// NewTracerProvider returns a provider batching spans to an OTLP gRPC
+// collector. The caller must Shutdown it to flush the last spans.
+func NewTracerProvider(ctx context.Context, opts Options) (*sdktrace.TracerProvider, error) {
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", opts.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	), nil
+}
+
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ observability.Tracer = Tracer{}
+
+func NewTracer(provider trace.TracerProvider) Tracer {
+	return Tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+func (t Tracer) Start(ctx context.Context, name string, attrs ...observability.Attribute) (context.Context, observability.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, Span{span: span}
+}
+
+func (Tracer) SpanFromContext(ctx context.Context) observability.Span {
+	return Span{span: trace.SpanFromContext(ctx)}
+}
+
+type Span struct {
+	span trace.Span
+}
+
+func (s Span) SetAttributes(attrs ...observability.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+func (s Span) Fail(err error, attrs ...observability.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s Span) End() {
+	s.span.End()
+}
+
+func convert(attrs []observability.Attribute) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		switch value := attr.Value.(type) {
+		case string:
+			out = append(out, attribute.String(attr.Key, value))
+		case bool:
+			out = append(out, attribute.Bool(attr.Key, value))
+		case int:
+			out = append(out, attribute.Int(attr.Key, value))
+		}
+	}
+	return out
+}
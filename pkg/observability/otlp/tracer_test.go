@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+)
+
+func TestTracer_NestsSpansAndRecordsFailures(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := tracer.Start(context.Background(), observability.SpanReconcile,
+		observability.String(observability.AttrPolicy, "default/policy"))
+	_, child := tracer.Start(ctx, observability.SpanReconcileNamespace,
+		observability.String(observability.AttrNamespace, "app-1"))
+	child.Fail(errors.New("boom"), observability.String(observability.AttrErrorReason, "Forbidden"))
+	child.End()
+	tracer.SpanFromContext(ctx).SetAttributes(observability.Bool(observability.AttrFastPath, false))
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended %d spans, want 2", len(spans))
+	}
+	ns, reconcile := spans[0], spans[1]
+	if ns.Parent().SpanID() != reconcile.SpanContext().SpanID() {
+		t.Fatalf("namespace span is not a child of the reconcile span")
+	}
+	if ns.Status().Code != codes.Error || ns.Status().Description != "boom" {
+		t.Fatalf("namespace span status = %+v, want error boom", ns.Status())
+	}
+	wantAttr(t, ns.Attributes(), attribute.String(observability.AttrErrorReason, "Forbidden"))
+	wantAttr(t, reconcile.Attributes(), attribute.String(observability.AttrPolicy, "default/policy"))
+	wantAttr(t, reconcile.Attributes(), attribute.Bool(observability.AttrFastPath, false))
+}
+
+func wantAttr(t *testing.T, attrs []attribute.KeyValue, want attribute.KeyValue) {
+	t.Helper()
+	for _, attr := range attrs {
+		if attr == want {
+			return
+		}
+	}
+	t.Fatalf("attributes %v do not contain %v", attrs, want)
+}
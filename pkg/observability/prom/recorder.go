@@ -4,6 +4,8 @@
 package prom
 
 import (
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,16 +13,43 @@ import (
 	"github.com/lapacek-labs/identity-operator/pkg/observability"
 )
 
+// Options bound what the Recorder exposes.
+type Options struct {
+	// NamespaceTopN is the number of target namespaces with the most out of
+	// sync targets exposed with a namespace label. Zero exposes none.
+	NamespaceTopN int
+}
+
 type Recorder struct {
+	options Options
+
 	reconcileTotal    *prometheus.CounterVec
 	reconcileDuration *prometheus.HistogramVec
 
 	fanoutTargetsTotal  prometheus.Counter
 	fanoutTargetsSynced prometheus.Counter
+	fanoutTargetsPruned prometheus.Counter
+	fanoutFailures      *prometheus.CounterVec
+
+	paused prometheus.Gauge
+
+	policies            *prometheus.GaugeVec
+	targetsOutOfSync    prometheus.Gauge
+	namespaceOutOfSync  *prometheus.GaugeVec
+	propagationLatency  prometheus.Histogram
+	propagationDuration prometheus.Histogram
+
+	// mu guards state, the last reported state of every policy the gauges
+	// are computed from.
+	mu    sync.Mutex
+	state map[string]observability.Policy
 }
 
-func NewRecorder(registerer prometheus.Registerer) *Recorder {
+func NewRecorder(registerer prometheus.Registerer, opts Options) *Recorder {
 	r := &Recorder{
+		options: opts,
+		state:   make(map[string]observability.Policy),
+
 		reconcileTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "identity_operator_reconcile_total",
@@ -51,6 +80,68 @@ func NewRecorder(registerer prometheus.Registerer) *Recorder {
 				Help: "Total number of fanout targets successfully synced (sum of targetsSynced over fanout reconciles).",
 			},
 		),
+
+		fanoutTargetsPruned: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "identity_operator_fanout_targets_pruned",
+				Help: "Total number of stale target objects deleted (sum of targetsPruned over fanout reconciles).",
+			},
+		),
+
+		fanoutFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "identity_operator_fanout_failures_total",
+				Help: "Total number of failed target writes and deletions by reason.",
+			},
+			[]string{"reason"},
+		),
+
+		paused: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "identity_operator_paused",
+				Help: "1 while the operator-wide pause switch stops all writes, 0 otherwise.",
+			},
+		),
+
+		policies: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "identity_operator_policies",
+				Help: "Number of policies by state (ready/degraded/unknown).",
+			},
+			[]string{"state"},
+		),
+
+		targetsOutOfSync: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "identity_operator_targets_out_of_sync",
+				Help: "Number of target namespaces, summed over policies, whose last sync failed.",
+			},
+		),
+
+		namespaceOutOfSync: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "identity_operator_namespace_targets_out_of_sync",
+				Help: "Number of policies whose last sync into the namespace failed, for the namespaces with the most.",
+			},
+			[]string{"namespace"},
+		),
+
+		propagationLatency: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "identity_operator_propagation_latency_seconds",
+				Help:    "Time from a source Secret change until it was synced to every target namespace.",
+				Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+			},
+		),
+
+		propagationDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name: "identity_operator_propagation_duration_seconds",
+				Help: "Time from the reconcile that first observed a source change until it was synced to every " +
+					"target namespace, across reconciles.",
+				Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+			},
+		),
 	}
 
 	registerer.MustRegister(
@@ -58,7 +149,17 @@ func NewRecorder(registerer prometheus.Registerer) *Recorder {
 		r.reconcileDuration,
 		r.fanoutTargetsTotal,
 		r.fanoutTargetsSynced,
+		r.fanoutTargetsPruned,
+		r.fanoutFailures,
+		r.paused,
+		r.policies,
+		r.targetsOutOfSync,
+		r.propagationLatency,
+		r.propagationDuration,
 	)
+	if opts.NamespaceTopN > 0 {
+		registerer.MustRegister(r.namespaceOutOfSync)
+	}
 
 	return r
 }
@@ -79,4 +180,94 @@ func (r *Recorder) RecordFanout(fanout observability.Fanout) {
 	// If you later need per-outcome/per-reason fanout metrics, add a separate labeled vec.
 	r.fanoutTargetsTotal.Add(float64(fanout.Total))
 	r.fanoutTargetsSynced.Add(float64(fanout.Success))
+	r.fanoutTargetsPruned.Add(float64(fanout.Pruned))
+	// Reasons are a closed set, see errclass.AllReasons.
+	for reason, count := range fanout.FailureReasons {
+		r.fanoutFailures.WithLabelValues(string(reason)).Add(float64(count))
+	}
+}
+
+func (r *Recorder) RecordPaused(paused bool) {
+	if paused {
+		r.paused.Set(1)
+		return
+	}
+	r.paused.Set(0)
+}
+
+func (r *Recorder) RecordPolicy(policy observability.Policy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state[policy.Key] = policy
+	r.updateGauges()
+}
+
+func (r *Recorder) ForgetPolicy(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.state[key]; !ok {
+		return
+	}
+	delete(r.state, key)
+	r.updateGauges()
+}
+
+func (r *Recorder) RecordPropagation(latency time.Duration) {
+	r.propagationLatency.Observe(latency.Seconds())
+}
+
+func (r *Recorder) RecordPropagationCompleted(duration time.Duration) {
+	r.propagationDuration.Observe(duration.Seconds())
+}
+
+// updateGauges recomputes the policy gauges from state. Must be called with mu held.
+func (r *Recorder) updateGauges() {
+	byState := map[observability.PolicyState]int{
+		observability.PolicyReady:    0,
+		observability.PolicyDegraded: 0,
+		observability.PolicyUnknown:  0,
+	}
+	outOfSync := 0
+	byNamespace := make(map[string]int)
+	for _, policy := range r.state {
+		byState[policy.State]++
+		outOfSync += policy.OutOfSync
+		for _, namespace := range policy.OutOfSyncNamespaces {
+			byNamespace[namespace]++
+		}
+	}
+	for state, count := range byState {
+		r.policies.WithLabelValues(string(state)).Set(float64(count))
+	}
+	r.targetsOutOfSync.Set(float64(outOfSync))
+
+	if r.options.NamespaceTopN <= 0 {
+		return
+	}
+	r.namespaceOutOfSync.Reset()
+	for _, namespace := range topNamespaces(byNamespace, r.options.NamespaceTopN) {
+		r.namespaceOutOfSync.WithLabelValues(namespace).Set(float64(byNamespace[namespace]))
+	}
+}
+
+// topNamespaces returns the n namespaces with the highest counts. Ties are
+// broken by name so the exposed set is stable between scrapes.
+func topNamespaces(counts map[string]int, n int) []string {
+	namespaces := make([]string, 0, len(counts))
+	for namespace := range counts {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		ci, cj := counts[namespaces[i]], counts[namespaces[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return namespaces[i] < namespaces[j]
+	})
+	if len(namespaces) > n {
+		namespaces = namespaces[:n]
+	}
+	return namespaces
 }
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package prom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/lapacek-labs/identity-operator/pkg/errclass"
+	"github.com/lapacek-labs/identity-operator/pkg/observability"
+)
+
+func TestRecorder_PolicyGauges(t *testing.T) {
+	r := NewRecorder(prometheus.NewRegistry(), Options{NamespaceTopN: 1})
+
+	r.RecordPolicy(observability.Policy{
+		Key:                 "default/a",
+		State:               observability.PolicyDegraded,
+		OutOfSync:           2,
+		OutOfSyncNamespaces: []string{"app-1", "app-2"},
+	})
+	r.RecordPolicy(observability.Policy{
+		Key:   "default/b",
+		State: observability.PolicyDegraded,
+		// Failed namespaces beyond the status list are still counted.
+		OutOfSync:           3,
+		OutOfSyncNamespaces: []string{"app-2"},
+	})
+	r.RecordPolicy(observability.Policy{Key: "default/c", State: observability.PolicyReady})
+
+	if got := testutil.ToFloat64(r.policies.WithLabelValues(string(observability.PolicyDegraded))); got != 2 {
+		t.Fatalf("degraded policies = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(r.targetsOutOfSync); got != 5 {
+		t.Fatalf("targets out of sync = %v, want 5", got)
+	}
+	want := `
+# HELP identity_operator_namespace_targets_out_of_sync Number of policies whose last sync into the namespace failed, for the namespaces with the most.
+# TYPE identity_operator_namespace_targets_out_of_sync gauge
+identity_operator_namespace_targets_out_of_sync{namespace="app-2"} 2
+`
+	if err := testutil.CollectAndCompare(r.namespaceOutOfSync, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+
+	r.ForgetPolicy("default/b")
+	if got := testutil.ToFloat64(r.targetsOutOfSync); got != 2 {
+		t.Fatalf("targets out of sync after forget = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(r.policies.WithLabelValues(string(observability.PolicyDegraded))); got != 1 {
+		t.Fatalf("degraded policies after forget = %v, want 1", got)
+	}
+}
+
+func TestRecorder_FanoutFailuresByReason(t *testing.T) {
+	r := NewRecorder(prometheus.NewRegistry(), Options{})
+
+	r.RecordFanout(observability.Fanout{
+		Total:  3,
+		Failed: 3,
+		FailureReasons: map[errclass.ErrorReason]int{
+			errclass.ReasonForbidden: 2,
+			errclass.ReasonTimeout:   1,
+		},
+	})
+
+	if got := testutil.ToFloat64(r.fanoutFailures.WithLabelValues(string(errclass.ReasonForbidden))); got != 2 {
+		t.Fatalf("forbidden failures = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(r.fanoutFailures.WithLabelValues(string(errclass.ReasonTimeout))); got != 1 {
+		t.Fatalf("timeout failures = %v, want 1", got)
+	}
+}
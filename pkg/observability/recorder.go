@@ -10,4 +10,17 @@ import (
 type Recorder interface {
 	RecordAttempt(attempt Attempt, latency time.Duration)
 	RecordFanout(fanout Fanout)
+	// RecordPaused reports the state of the operator-wide pause switch.
+	RecordPaused(paused bool)
+	// RecordPolicy reports the state of a policy after a reconcile.
+	RecordPolicy(policy Policy)
+	// ForgetPolicy drops a deleted policy from everything RecordPolicy reported.
+	ForgetPolicy(key string)
+	// RecordPropagation reports how long a source Secret change took to reach
+	// every target namespace.
+	RecordPropagation(latency time.Duration)
+	// RecordPropagationCompleted reports how long a source change took from the
+	// reconcile that first observed it until it reached every target namespace,
+	// across as many reconciles as it needed.
+	RecordPropagationCompleted(duration time.Duration)
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Simon Lapacek
+// SPDX-License-Identifier: MIT
+
+package observability
+
+import (
+	"context"
+)
+
+// Tracer starts spans around the steps of a reconcile.
+type Tracer interface {
+	// Start starts a span as a child of the span in ctx, if any, and returns a
+	// context carrying the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	// SpanFromContext returns the span carried by ctx, or a span that records
+	// nothing when there is none.
+	SpanFromContext(ctx context.Context) Span
+}
+
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// Fail records err on the span and marks it as failed.
+	Fail(err error, attrs ...Attribute)
+	End()
+}
+
+// Span names.
+const (
+	SpanReconcile          = "Reconcile"
+	SpanLoadSources        = "LoadSources"
+	SpanReconcileNamespace = "ReconcileNamespace"
+	SpanPatchStatus        = "PatchStatus"
+)
+
+// Attribute keys.
+const (
+	AttrPolicy         = "identity.policy"
+	AttrNamespace      = "identity.namespace"
+	AttrPhase          = "identity.phase"
+	AttrOutcome        = "identity.outcome"
+	AttrReason         = "identity.reason"
+	AttrErrorKind      = "identity.error.kind"
+	AttrErrorReason    = "identity.error.reason"
+	AttrFastPath       = "identity.fast_path"
+	AttrPatched        = "identity.status_patched"
+	AttrSources        = "identity.sources"
+	AttrMissingSources = "identity.sources_missing"
+	AttrTargets        = "identity.targets"
+)
+
+// Attribute is a span attribute. Value is a string, bool or int.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
@@ -9,4 +9,6 @@ const (
 	OutcomeSuccess Outcome = "success"
 	OutcomePartial Outcome = "partial"
 	OutcomeFailed  Outcome = "failed"
+	// OutcomeSkipped is a reconcile that deliberately did nothing.
+	OutcomeSkipped Outcome = "skipped"
 )
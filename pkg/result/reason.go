@@ -6,12 +6,29 @@ package result
 type Reason string
 
 const (
-	ReasonAPIServerError Reason = "APIServerError"
-	ReasonPartialFailure Reason = "PartialFailure"
-	ReasonInvalidSpec    Reason = "InvalidSpec"
-	ReasonForbidden      Reason = "Forbidden"
-	ReasonConflict       Reason = "Conflict"
-	ReasonNotFound       Reason = "NotFound"
-	ReasonTimeout        Reason = "Timeout"
-	ReasonUnknown        Reason = "Unknown"
+	ReasonAPIServerError       Reason = "APIServerError"
+	ReasonPartialFailure       Reason = "PartialFailure"
+	ReasonInvalidSpec          Reason = "InvalidSpec"
+	ReasonForbidden            Reason = "Forbidden"
+	ReasonConflict             Reason = "Conflict"
+	ReasonNotFound             Reason = "NotFound"
+	ReasonTimeout              Reason = "Timeout"
+	ReasonTargetConflict       Reason = "TargetConflict"
+	ReasonFieldManagerConflict Reason = "FieldManagerConflict"
+	ReasonUnknown              Reason = "Unknown"
 )
+
+func AllReasons() []Reason {
+	return []Reason{
+		ReasonAPIServerError,
+		ReasonPartialFailure,
+		ReasonInvalidSpec,
+		ReasonForbidden,
+		ReasonConflict,
+		ReasonNotFound,
+		ReasonTimeout,
+		ReasonTargetConflict,
+		ReasonFieldManagerConflict,
+		ReasonUnknown,
+	}
+}
@@ -84,6 +84,21 @@ func (cs *ConditionSet) Changed() bool {
 	return false
 }
 
+// Transition returns the status of condType before and after this reconcile.
+// A condition that was or is not set yields an empty status.
+func (cs *ConditionSet) Transition(condType string) (from, to metav1.ConditionStatus) {
+	for _, prev := range cs.original {
+		if prev.Type == condType {
+			from = prev.Status
+			break
+		}
+	}
+	if next, ok := cs.conditions[condType]; ok {
+		to = next.Status
+	}
+	return from, to
+}
+
 func (cs *ConditionSet) isSame(prev, next metav1.Condition) bool {
 	return prev.Status == next.Status &&
 		prev.Reason == next.Reason &&
@@ -270,3 +270,24 @@ func TestConditions_DebugDump_SortedDeterministic(t *testing.T) {
 		t.Fatalf("expected deterministic order Alpha,Zed; got %s", out)
 	}
 }
+
+func TestTransition(t *testing.T) {
+	t0 := mustTime(2026, time.January, 2, 10, 0)
+
+	existing := []metav1.Condition{
+		mustCond("Degraded", metav1.ConditionTrue, "Err", "failed", 1, t0),
+	}
+	cs := NewConditionSet(existing, 1, t0)
+	cs.Set("Degraded", metav1.ConditionFalse, "Ok", "ok")
+	cs.Set("Ready", metav1.ConditionTrue, "Ok", "ok")
+
+	if from, to := cs.Transition("Degraded"); from != metav1.ConditionTrue || to != metav1.ConditionFalse {
+		t.Fatalf("Degraded: expected True->False, got %q->%q", from, to)
+	}
+	if from, to := cs.Transition("Ready"); from != "" || to != metav1.ConditionTrue {
+		t.Fatalf("Ready: expected \"\"->True, got %q->%q", from, to)
+	}
+	if from, to := cs.Transition("Missing"); from != "" || to != "" {
+		t.Fatalf("Missing: expected no transition, got %q->%q", from, to)
+	}
+}